@@ -2,6 +2,8 @@ package gonertia
 
 import (
 	"context"
+	"maps"
+	"net/http"
 )
 
 type contextKey int
@@ -10,6 +12,16 @@ const (
 	templateDataContextKey = contextKey(iota + 1)
 	propsContextKey
 	validationErrorsContextKey
+	flashPropsContextKey
+	encryptHistoryContextKey
+	clearHistoryContextKey
+	disableSSRContextKey
+	disableSSRCacheContextKey
+	rootTemplateContextKey
+	cspNonceContextKey
+	headContextKey
+	responseWriterContextKey
+	requestContextKey
 )
 
 // SetTemplateData sets template data to the passed context.Context.
@@ -83,3 +95,166 @@ func ValidationErrorsFromContext(ctx context.Context) ValidationErrors {
 	}
 	return ValidationErrors{}
 }
+
+// FlashProp sets prop value, that will be flashed to the next request on redirect, to the passed context.Context.
+//
+// Flash provider must implement FlashPropsProvider interface.
+func FlashProp(ctx context.Context, key string, val any) context.Context {
+	props := FlashPropsFromContext(ctx)
+	props[key] = val
+	return context.WithValue(ctx, flashPropsContextKey, props)
+}
+
+// FlashPropsFromContext returns props, that will be flashed on redirect, from the context.
+func FlashPropsFromContext(ctx context.Context) Props {
+	props, ok := ctx.Value(flashPropsContextKey).(Props)
+	if ok {
+		return props
+	}
+	return Props{}
+}
+
+// SetEncryptHistory enables or disables history encryption for the current request.
+func SetEncryptHistory(ctx context.Context, encrypt ...bool) context.Context {
+	return context.WithValue(ctx, encryptHistoryContextKey, firstOr[bool](encrypt, true))
+}
+
+// EncryptHistoryFromContext returns history encryption value from the context.
+// The second value reports whether the value was set.
+func EncryptHistoryFromContext(ctx context.Context) (bool, bool) {
+	encryptHistory, ok := ctx.Value(encryptHistoryContextKey).(bool)
+	return encryptHistory, ok
+}
+
+// clearHistoryFlashKey is a key of the flashed props, that carries the clear history flag to the next request.
+const clearHistoryFlashKey = "__inertia_clear_history"
+
+// ClearHistory marks that the history state should be cleared by the client.
+//
+// If the request ends with redirect, the flag is passed to the next request
+// using the flash provider, that implements FlashPropsProvider.
+func ClearHistory(ctx context.Context) context.Context {
+	return context.WithValue(ctx, clearHistoryContextKey, true)
+}
+
+// ClearHistoryFromContext returns true if the history state should be cleared.
+func ClearHistoryFromContext(ctx context.Context) bool {
+	clearHistory, ok := ctx.Value(clearHistoryContextKey).(bool)
+	if ok {
+		return clearHistory
+	}
+	return false
+}
+
+// DisableSSR disables server side rendering for the request,
+// so the page will be rendered on the client side.
+func DisableSSR(ctx context.Context) context.Context {
+	return context.WithValue(ctx, disableSSRContextKey, true)
+}
+
+// SSRDisabledFromContext returns true if server side rendering is disabled for the request.
+func SSRDisabledFromContext(ctx context.Context) bool {
+	disableSSR, ok := ctx.Value(disableSSRContextKey).(bool)
+	if ok {
+		return disableSSR
+	}
+	return false
+}
+
+// DisableSSRCache disables caching of the server side rendered page for the request
+// (e.g. for authenticated users, whose pages are personalized).
+func DisableSSRCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, disableSSRCacheContextKey, true)
+}
+
+// SSRCacheDisabledFromContext returns true if caching of the server side rendered page is disabled for the request.
+func SSRCacheDisabledFromContext(ctx context.Context) bool {
+	disableSSRCache, ok := ctx.Value(disableSSRCacheContextKey).(bool)
+	if ok {
+		return disableSSRCache
+	}
+	return false
+}
+
+// SetRootTemplate sets the name of the root template, that will be used to render the page.
+//
+// Root template is either registered by WithRootTemplate or is a template from the set, parsed by NewFromFS.
+func SetRootTemplate(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, rootTemplateContextKey, name)
+}
+
+// RootTemplateFromContext returns the name of the root template from the context.
+func RootTemplateFromContext(ctx context.Context) string {
+	name, ok := ctx.Value(rootTemplateContextKey).(string)
+	if ok {
+		return name
+	}
+	return ""
+}
+
+// SetCSPNonce sets the Content-Security-Policy nonce to the passed context.Context.
+// It's useful if the policy is set by other middleware, otherwise the nonce is generated by Inertia (see WithCSP).
+func SetCSPNonce(ctx context.Context, nonce string) context.Context {
+	return context.WithValue(ctx, cspNonceContextKey, nonce)
+}
+
+// CSPNonceFromContext returns the Content-Security-Policy nonce from the context.
+func CSPNonceFromContext(ctx context.Context) string {
+	nonce, ok := ctx.Value(cspNonceContextKey).(string)
+	if ok {
+		return nonce
+	}
+	return ""
+}
+
+// SetTitle sets the page title, that is rendered on the server side (see Head).
+func SetTitle(ctx context.Context, title string) context.Context {
+	head := HeadFromContext(ctx)
+	head.Title = title
+	return context.WithValue(ctx, headContextKey, head)
+}
+
+// AddMeta adds the meta tag with passed attributes, that is rendered on the server side (see Head).
+//
+// Meta tags are identified by "head-key", "name", "property" or "http-equiv" attribute
+// (the first one, that is set), so the tag with the same key replaces the previous one.
+func AddMeta(ctx context.Context, attrs map[string]string) context.Context {
+	head := HeadFromContext(ctx)
+
+	key := metaKey(attrs)
+	meta := make([]map[string]string, 0, len(head.Meta)+1)
+	for _, m := range head.Meta {
+		if key == "" || metaKey(m) != key {
+			meta = append(meta, m)
+		}
+	}
+	head.Meta = append(meta, maps.Clone(attrs))
+
+	return context.WithValue(ctx, headContextKey, head)
+}
+
+// HeadFromContext returns the page head from the context.
+func HeadFromContext(ctx context.Context) Head {
+	head, ok := ctx.Value(headContextKey).(Head)
+	if ok {
+		return head
+	}
+	return Head{}
+}
+
+// setHTTP sets response writer and request to the passed context.Context,
+// so they can be used by the flash data providers.
+func setHTTP(ctx context.Context, w http.ResponseWriter, r *http.Request) context.Context {
+	ctx = context.WithValue(ctx, responseWriterContextKey, w)
+	return context.WithValue(ctx, requestContextKey, r)
+}
+
+func responseWriterFromContext(ctx context.Context) http.ResponseWriter {
+	w, _ := ctx.Value(responseWriterContextKey).(http.ResponseWriter)
+	return w
+}
+
+func requestFromContext(ctx context.Context) *http.Request {
+	r, _ := ctx.Value(requestContextKey).(*http.Request)
+	return r
+}
@@ -418,3 +418,534 @@ func Test_ValidationErrorsFromContext(t *testing.T) {
 		})
 	}
 }
+
+func TestInertia_FlashProp(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fresh", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := FlashProp(context.Background(), "foo", "bar")
+
+		got, ok := ctx.Value(flashPropsContextKey).(Props)
+		if !ok {
+			t.Fatal("flash props from context is not `Props` type")
+		}
+
+		want := Props{"foo": "bar"}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("Props=%#v, want=%#v", got, want)
+		}
+	})
+
+	t.Run("already filled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.WithValue(context.Background(), flashPropsContextKey, Props{"baz": "quz", "foo": "quz"})
+		ctx = FlashProp(ctx, "foo", "bar")
+
+		got, ok := ctx.Value(flashPropsContextKey).(Props)
+		if !ok {
+			t.Fatal("flash props from context is not `Props` type")
+		}
+
+		want := Props{"foo": "bar", "baz": "quz"}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("Props=%#v, want=%#v", got, want)
+		}
+	})
+}
+
+func Test_FlashPropsFromContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ctxData any
+		want    Props
+	}{
+		{
+			name:    "nil",
+			ctxData: nil,
+			want:    Props{},
+		},
+		{
+			name:    "filled",
+			ctxData: Props{"foo": "bar"},
+			want:    Props{"foo": "bar"},
+		},
+		{
+			name:    "wrong type",
+			ctxData: []string{"foo", "bar"},
+			want:    Props{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.WithValue(context.Background(), flashPropsContextKey, tt.ctxData)
+
+			got := FlashPropsFromContext(ctx)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Props=%#v, want=%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInertia_SetEncryptHistory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := SetEncryptHistory(context.Background())
+
+		got, ok := ctx.Value(encryptHistoryContextKey).(bool)
+		if !ok {
+			t.Fatal("encrypt history from context is not `bool` type")
+		}
+
+		if !got {
+			t.Fatalf("EncryptHistory=%t, want=%t", got, true)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := SetEncryptHistory(context.Background(), false)
+
+		got, ok := ctx.Value(encryptHistoryContextKey).(bool)
+		if !ok {
+			t.Fatal("encrypt history from context is not `bool` type")
+		}
+
+		if got {
+			t.Fatalf("EncryptHistory=%t, want=%t", got, false)
+		}
+	})
+}
+
+func Test_EncryptHistoryFromContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ctxData any
+		want    bool
+		wantOk  bool
+	}{
+		{
+			name:    "nil",
+			ctxData: nil,
+			want:    false,
+			wantOk:  false,
+		},
+		{
+			name:    "true",
+			ctxData: true,
+			want:    true,
+			wantOk:  true,
+		},
+		{
+			name:    "false",
+			ctxData: false,
+			want:    false,
+			wantOk:  true,
+		},
+		{
+			name:    "wrong type",
+			ctxData: "foo",
+			want:    false,
+			wantOk:  false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.WithValue(context.Background(), encryptHistoryContextKey, tt.ctxData)
+
+			got, ok := EncryptHistoryFromContext(ctx)
+			if got != tt.want || ok != tt.wantOk {
+				t.Fatalf("EncryptHistory=%t, ok=%t, want=%t, wantOk=%t", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestInertia_ClearHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := ClearHistory(context.Background())
+
+	got, ok := ctx.Value(clearHistoryContextKey).(bool)
+	if !ok {
+		t.Fatal("clear history from context is not `bool` type")
+	}
+
+	if !got {
+		t.Fatalf("ClearHistory=%t, want=%t", got, true)
+	}
+}
+
+func Test_ClearHistoryFromContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ctxData any
+		want    bool
+	}{
+		{
+			name:    "nil",
+			ctxData: nil,
+			want:    false,
+		},
+		{
+			name:    "true",
+			ctxData: true,
+			want:    true,
+		},
+		{
+			name:    "wrong type",
+			ctxData: "foo",
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.WithValue(context.Background(), clearHistoryContextKey, tt.ctxData)
+
+			got := ClearHistoryFromContext(ctx)
+			if got != tt.want {
+				t.Fatalf("ClearHistory=%t, want=%t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInertia_DisableSSR(t *testing.T) {
+	t.Parallel()
+
+	ctx := DisableSSR(context.Background())
+
+	got, ok := ctx.Value(disableSSRContextKey).(bool)
+	if !ok {
+		t.Fatal("disable ssr from context is not `bool` type")
+	}
+
+	if !got {
+		t.Fatalf("DisableSSR=%t, want=%t", got, true)
+	}
+}
+
+func Test_SSRDisabledFromContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ctxData any
+		want    bool
+	}{
+		{
+			name:    "nil",
+			ctxData: nil,
+			want:    false,
+		},
+		{
+			name:    "true",
+			ctxData: true,
+			want:    true,
+		},
+		{
+			name:    "wrong type",
+			ctxData: "foo",
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.WithValue(context.Background(), disableSSRContextKey, tt.ctxData)
+
+			got := SSRDisabledFromContext(ctx)
+			if got != tt.want {
+				t.Fatalf("SSRDisabled=%t, want=%t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInertia_DisableSSRCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := DisableSSRCache(context.Background())
+
+	got, ok := ctx.Value(disableSSRCacheContextKey).(bool)
+	if !ok {
+		t.Fatal("disable ssr cache from context is not `bool` type")
+	}
+
+	if !got {
+		t.Fatalf("DisableSSRCache=%t, want=%t", got, true)
+	}
+}
+
+func Test_SSRCacheDisabledFromContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ctxData any
+		want    bool
+	}{
+		{
+			name:    "nil",
+			ctxData: nil,
+			want:    false,
+		},
+		{
+			name:    "true",
+			ctxData: true,
+			want:    true,
+		},
+		{
+			name:    "wrong type",
+			ctxData: "foo",
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.WithValue(context.Background(), disableSSRCacheContextKey, tt.ctxData)
+
+			got := SSRCacheDisabledFromContext(ctx)
+			if got != tt.want {
+				t.Fatalf("SSRCacheDisabled=%t, want=%t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInertia_SetRootTemplate(t *testing.T) {
+	t.Parallel()
+
+	ctx := SetRootTemplate(context.Background(), "admin")
+
+	got, ok := ctx.Value(rootTemplateContextKey).(string)
+	if !ok {
+		t.Fatal("root template from context is not `string` type")
+	}
+
+	if got != "admin" {
+		t.Fatalf("SetRootTemplate=%s, want=%s", got, "admin")
+	}
+}
+
+func Test_RootTemplateFromContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ctxData any
+		want    string
+	}{
+		{
+			name:    "nil",
+			ctxData: nil,
+			want:    "",
+		},
+		{
+			name:    "with value",
+			ctxData: "admin",
+			want:    "admin",
+		},
+		{
+			name:    "wrong type",
+			ctxData: 123,
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.WithValue(context.Background(), rootTemplateContextKey, tt.ctxData)
+
+			got := RootTemplateFromContext(ctx)
+			if got != tt.want {
+				t.Fatalf("RootTemplate=%s, want=%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInertia_SetCSPNonce(t *testing.T) {
+	t.Parallel()
+
+	ctx := SetCSPNonce(context.Background(), "foo")
+
+	got, ok := ctx.Value(cspNonceContextKey).(string)
+	if !ok {
+		t.Fatal("csp nonce from context is not `string` type")
+	}
+
+	if got != "foo" {
+		t.Fatalf("SetCSPNonce=%s, want=%s", got, "foo")
+	}
+}
+
+func Test_CSPNonceFromContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ctxData any
+		want    string
+	}{
+		{
+			name:    "nil",
+			ctxData: nil,
+			want:    "",
+		},
+		{
+			name:    "with value",
+			ctxData: "foo",
+			want:    "foo",
+		},
+		{
+			name:    "wrong type",
+			ctxData: 123,
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.WithValue(context.Background(), cspNonceContextKey, tt.ctxData)
+
+			got := CSPNonceFromContext(ctx)
+			if got != tt.want {
+				t.Fatalf("CSPNonce=%s, want=%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInertia_SetTitle(t *testing.T) {
+	t.Parallel()
+
+	ctx := AddMeta(context.Background(), map[string]string{"name": "description", "content": "foo"})
+	ctx = SetTitle(ctx, "foo")
+	ctx = SetTitle(ctx, "bar")
+
+	got, ok := ctx.Value(headContextKey).(Head)
+	if !ok {
+		t.Fatal("head from context is not `Head` type")
+	}
+
+	want := Head{
+		Title: "bar",
+		Meta:  []map[string]string{{"name": "description", "content": "foo"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Head=%#v, want=%#v", got, want)
+	}
+}
+
+func TestInertia_AddMeta(t *testing.T) {
+	t.Parallel()
+
+	parent := AddMeta(context.Background(), map[string]string{"name": "description", "content": "foo"})
+	parent = AddMeta(parent, map[string]string{"charset": "utf-8"})
+
+	ctx := AddMeta(parent, map[string]string{"property": "og:title", "content": "bar"})
+	ctx = AddMeta(ctx, map[string]string{"name": "description", "content": "baz"})
+
+	want := Head{
+		Meta: []map[string]string{
+			{"charset": "utf-8"},
+			{"property": "og:title", "content": "bar"},
+			{"name": "description", "content": "baz"},
+		},
+	}
+	if got := HeadFromContext(ctx); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Head=%#v, want=%#v", got, want)
+	}
+
+	// Parent context is not modified.
+	wantParent := Head{
+		Meta: []map[string]string{
+			{"name": "description", "content": "foo"},
+			{"charset": "utf-8"},
+		},
+	}
+	if got := HeadFromContext(parent); !reflect.DeepEqual(got, wantParent) {
+		t.Fatalf("parent Head=%#v, want=%#v", got, wantParent)
+	}
+}
+
+func Test_HeadFromContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ctxData any
+		want    Head
+	}{
+		{
+			name:    "nil",
+			ctxData: nil,
+			want:    Head{},
+		},
+		{
+			name:    "with value",
+			ctxData: Head{Title: "foo"},
+			want:    Head{Title: "foo"},
+		},
+		{
+			name:    "wrong type",
+			ctxData: "foo",
+			want:    Head{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.WithValue(context.Background(), headContextKey, tt.ctxData)
+
+			got := HeadFromContext(ctx)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Head=%#v, want=%#v", got, tt.want)
+			}
+		})
+	}
+}
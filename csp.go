@@ -0,0 +1,74 @@
+package gonertia
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// CSPNoncePlaceholder is a placeholder in the Content-Security-Policy, that is replaced by the request nonce.
+const CSPNoncePlaceholder = "{nonce}"
+
+const cspNonceSize = 16
+
+// withCSPNonce generates the nonce for the request (if it's not set in the context yet)
+// and sets Content-Security-Policy header with it.
+func (i *Inertia) withCSPNonce(w http.ResponseWriter, r *http.Request) (*http.Request, error) {
+	if i.cspPolicy == "" {
+		return r, nil
+	}
+
+	nonce := CSPNonceFromContext(r.Context())
+	if nonce == "" {
+		var err error
+		nonce, err = generateCSPNonce()
+		if err != nil {
+			return nil, err
+		}
+
+		r = r.WithContext(SetCSPNonce(r.Context(), nonce))
+	}
+
+	w.Header().Set("Content-Security-Policy", strings.ReplaceAll(i.cspPolicy, CSPNoncePlaceholder, nonce))
+
+	return r, nil
+}
+
+func generateCSPNonce() (string, error) {
+	bs := make([]byte, cspNonceSize)
+	if _, err := rand.Read(bs); err != nil {
+		return "", fmt.Errorf("generate csp nonce: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bs), nil
+}
+
+var headTagRe = regexp.MustCompile(`(?i)<(script|style)\b[^>]*>`)
+
+// headWithNonce adds the nonce attribute to the script and style tags of the head, that don't have it.
+func headWithNonce(head []string, nonce string) []string {
+	if nonce == "" {
+		return head
+	}
+
+	attr := ` nonce="` + template.HTMLEscapeString(nonce) + `"`
+
+	result := make([]string, len(head))
+	for idx, tag := range head {
+		result[idx] = headTagRe.ReplaceAllStringFunc(tag, func(tag string) string {
+			if strings.Contains(strings.ToLower(tag), "nonce=") {
+				return tag
+			}
+
+			// Insert after the tag name: "<script" or "<style".
+			nameEnd := strings.IndexAny(tag, " \t\n\r\f/>")
+			return tag[:nameEnd] + attr + tag[nameEnd:]
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,166 @@
+package gonertia
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestInertia_Render_csp(t *testing.T) {
+	t.Parallel()
+
+	t.Run("generated nonce", func(t *testing.T) {
+		t.Parallel()
+
+		i := I(func(i *Inertia) {
+			i.rootTemplateHTML = `<script nonce="{{ .cspNonce }}"></script>`
+			i.cspPolicy = "script-src 'nonce-{nonce}'"
+		})
+
+		nonces := make(map[string]struct{})
+
+		for range 2 {
+			w, r := requestMock(http.MethodGet, "/")
+
+			if err := i.Render(w, r, "Some/Component"); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			matches := regexp.MustCompile(`^script-src 'nonce-([^']+)'$`).
+				FindStringSubmatch(w.Header().Get("Content-Security-Policy"))
+			if len(matches) != 2 {
+				t.Fatalf("content security policy=%s", w.Header().Get("Content-Security-Policy"))
+			}
+
+			nonce := matches[1]
+			if want := `<script nonce="` + nonce + `"></script>`; w.Body.String() != want {
+				t.Fatalf("got=%s, want=%s", w.Body.String(), want)
+			}
+
+			nonces[nonce] = struct{}{}
+		}
+
+		if len(nonces) != 2 {
+			t.Fatal("nonce is not unique per request")
+		}
+	})
+
+	t.Run("nonce from context", func(t *testing.T) {
+		t.Parallel()
+
+		i := I(func(i *Inertia) {
+			i.rootTemplateHTML = `{{ .inertiaHead }}`
+			i.cspPolicy = "script-src 'nonce-{nonce}'"
+			i.ssr = &ssrRendererMock{head: []string{`<script>foo</script>`, `<title inertia>bar</title>`}}
+		})
+
+		w, r := requestMock(http.MethodGet, "/")
+		r = r.WithContext(SetCSPNonce(r.Context(), "foo"))
+
+		if err := i.Render(w, r, "Some/Component"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got := w.Header().Get("Content-Security-Policy"); got != "script-src 'nonce-foo'" {
+			t.Fatalf("content security policy=%s, want=%s", got, "script-src 'nonce-foo'")
+		}
+
+		want := "<script nonce=\"foo\">foo</script>\n<title inertia>bar</title>"
+		if got := w.Body.String(); got != want {
+			t.Fatalf("got=%s, want=%s", got, want)
+		}
+	})
+
+	t.Run("inertia request", func(t *testing.T) {
+		t.Parallel()
+
+		i := I(func(i *Inertia) {
+			i.cspPolicy = "script-src 'nonce-{nonce}'"
+		})
+
+		w, r := requestMock(http.MethodGet, "/")
+		asInertiaRequest(r)
+
+		if err := i.Render(w, r, "Some/Component"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got := w.Header().Get("Content-Security-Policy"); got != "" {
+			t.Fatalf("content security policy=%s, want empty", got)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		i := I(func(i *Inertia) {
+			i.rootTemplateHTML = `{{ .cspNonce }}`
+		})
+
+		w, r := requestMock(http.MethodGet, "/")
+
+		if err := i.Render(w, r, "Some/Component"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got := w.Header().Get("Content-Security-Policy"); got != "" {
+			t.Fatalf("content security policy=%s, want empty", got)
+		}
+
+		if got := strings.TrimSpace(w.Body.String()); got != "" {
+			t.Fatalf("nonce=%s, want empty", got)
+		}
+	})
+}
+
+func Test_headWithNonce(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		head  []string
+		nonce string
+		want  []string
+	}{
+		{
+			"scripts and styles",
+			[]string{`<script>foo</script>`, `<style type="text/css">bar</style>`, `<SCRIPT src="/app.js"></SCRIPT>`},
+			"abc",
+			[]string{`<script nonce="abc">foo</script>`, `<style nonce="abc" type="text/css">bar</style>`, `<SCRIPT nonce="abc" src="/app.js"></SCRIPT>`},
+		},
+		{
+			"other tags",
+			[]string{`<title inertia>foo</title>`, `<meta name="description" content="&lt;script&gt;">`},
+			"abc",
+			[]string{`<title inertia>foo</title>`, `<meta name="description" content="&lt;script&gt;">`},
+		},
+		{
+			"already has nonce",
+			[]string{`<script nonce="xyz">foo</script>`},
+			"abc",
+			[]string{`<script nonce="xyz">foo</script>`},
+		},
+		{
+			"blank nonce",
+			[]string{`<script>foo</script>`},
+			"",
+			[]string{`<script>foo</script>`},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := headWithNonce(tt.head, tt.nonce)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("head=%#v, want=%#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package gonertia
+
+import (
+	"net/http"
+)
+
+// EarlyHintsConfig is a configuration of the 103 Early Hints, that are sent for full page visits.
+//
+// https://developer.mozilla.org/en-US/docs/Web/HTTP/Status/103
+type EarlyHintsConfig struct {
+	// Entries are the Vite entries, that are loaded by the root template (e.g. "resources/js/app.ts").
+	Entries []string
+
+	// ComponentChunk returns the Vite manifest key of the page component chunk
+	// (e.g. "resources/js/Pages/" + component + ".vue"), so the component is preloaded too.
+	// Optional, components, that are not found in the manifest, are skipped.
+	ComponentChunk func(component string) string
+
+	// InformationalResponses reports that the response writer passed to the handlers
+	// supports 1xx informational responses (e.g. the net/http server response writer).
+	// Middleware writers are unwrapped (see http.ResponseController), and the ones, that implement
+	// InformationalResponseWriter, decide themselves. Otherwise, only Link headers are added to the final response,
+	// because other writers (httptest.ResponseRecorder, logging or gzip middlewares, etc.) treat 103 as the final status.
+	InformationalResponses bool
+}
+
+// InformationalResponseWriter is an optional interface of http.ResponseWriter,
+// that reports whether it supports 1xx informational responses (e.g. 103 Early Hints).
+type InformationalResponseWriter interface {
+	SupportsInformationalResponses() bool
+}
+
+// sendEarlyHints adds Link preload headers of the page assets and sends them with 103 Early Hints,
+// so the browser can start fetching assets while the page is being built.
+// Link headers are also kept for the final response.
+//
+// 103 is sent only if the writer supports informational responses (see EarlyHintsConfig.InformationalResponses).
+func (i *Inertia) sendEarlyHints(w http.ResponseWriter, component string) {
+	if i.earlyHints == nil || i.vite == nil || i.vite.IsDev() {
+		return
+	}
+
+	entries := i.earlyHints.Entries
+	if i.earlyHints.ComponentChunk != nil {
+		if chunk := i.earlyHints.ComponentChunk(component); i.vite.hasChunk(chunk) {
+			entries = append(entries[:len(entries):len(entries)], chunk)
+		}
+	}
+
+	links, err := i.vite.linkHeaders(entries)
+	if err != nil {
+		i.logger.Printf("early hints error: %s", err)
+		return
+	}
+	if len(links) == 0 {
+		return
+	}
+
+	for _, link := range links {
+		w.Header().Add("Link", link)
+	}
+
+	if rw, ok := i.informationalResponseWriter(w); ok {
+		rw.WriteHeader(http.StatusEarlyHints)
+	}
+}
+
+// informationalResponseWriter returns the writer, which supports 1xx statuses, unwrapping middleware writers
+// (see http.ResponseController). The innermost writer is trusted if it's allowed by the config.
+func (i *Inertia) informationalResponseWriter(w http.ResponseWriter) (http.ResponseWriter, bool) {
+	for {
+		if iw, ok := w.(InformationalResponseWriter); ok {
+			return w, iw.SupportsInformationalResponses()
+		}
+
+		u, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return w, i.earlyHints.InformationalResponses
+		}
+		w = u.Unwrap()
+	}
+}
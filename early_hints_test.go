@@ -0,0 +1,313 @@
+package gonertia
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/textproto"
+	"reflect"
+	"testing"
+)
+
+func TestInertia_sendEarlyHints(t *testing.T) {
+	t.Parallel()
+
+	componentChunk := func(component string) string {
+		return "resources/js/Pages/" + component + ".vue"
+	}
+
+	tests := []struct {
+		name      string
+		manifest  string
+		config    EarlyHintsConfig
+		viteOpts  []ViteOption
+		component string
+		wantLinks []string
+	}{
+		{
+			name:      "entries",
+			config:    EarlyHintsConfig{Entries: []string{"resources/js/app.ts"}},
+			component: "Home",
+			wantLinks: []string{
+				"</build/assets/app-5f4e8a1b.css>; rel=preload; as=style",
+				"</build/assets/shared-0f1e2d3c.css>; rel=preload; as=style",
+				"</build/assets/app-4ed993c7.js>; rel=modulepreload",
+				"</build/assets/shared-b76e2dd1.js>; rel=modulepreload",
+			},
+		},
+		{
+			name:      "with component chunk",
+			config:    EarlyHintsConfig{Entries: []string{"resources/js/app.ts"}, ComponentChunk: componentChunk},
+			component: "Home",
+			wantLinks: []string{
+				"</build/assets/app-5f4e8a1b.css>; rel=preload; as=style",
+				"</build/assets/shared-0f1e2d3c.css>; rel=preload; as=style",
+				"</build/assets/app-4ed993c7.js>; rel=modulepreload",
+				"</build/assets/Home-0a1b2c3d.js>; rel=modulepreload",
+				"</build/assets/shared-b76e2dd1.js>; rel=modulepreload",
+			},
+		},
+		{
+			name:      "circular imports",
+			manifest:  viteCyclicManifest,
+			config:    EarlyHintsConfig{Entries: []string{"a.js"}},
+			component: "Home",
+			wantLinks: []string{
+				"</build/assets/a.js>; rel=modulepreload",
+				"</build/assets/b.js>; rel=modulepreload",
+				"</build/assets/c.js>; rel=modulepreload",
+			},
+		},
+		{
+			name:      "unknown component chunk",
+			config:    EarlyHintsConfig{ComponentChunk: componentChunk},
+			component: "Unknown",
+		},
+		{
+			name:      "vite dev server",
+			config:    EarlyHintsConfig{Entries: []string{"resources/js/app.ts"}},
+			viteOpts:  []ViteOption{WithViteDevServer("http://localhost:5173")},
+			component: "Home",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			manifest := tt.manifest
+			if manifest == "" {
+				manifest = viteManifest
+			}
+
+			f := tmpFile(t, manifest)
+
+			vite, err := NewVite(f.Name(), tt.viteOpts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			config := tt.config
+
+			i := I(func(i *Inertia) {
+				i.rootTemplateHTML = rootTemplate
+				i.vite = vite
+				i.earlyHints = &config
+			})
+
+			w, r := requestMock(http.MethodGet, "/")
+
+			if err = i.Render(w, r, tt.component); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := w.Header().Values("Link"); !reflect.DeepEqual(got, tt.wantLinks) {
+				t.Fatalf("links=%#v, want=%#v", got, tt.wantLinks)
+			}
+
+			// Recorder doesn't support informational responses, so 103 must not be sent.
+			if w.Code != http.StatusOK {
+				t.Fatalf("status=%d, want=%d", w.Code, http.StatusOK)
+			}
+		})
+	}
+
+	t.Run("inertia request", func(t *testing.T) {
+		t.Parallel()
+
+		f := tmpFile(t, viteManifest)
+
+		vite, err := NewVite(f.Name())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		i := I(func(i *Inertia) {
+			i.vite = vite
+			i.earlyHints = &EarlyHintsConfig{Entries: []string{"resources/js/app.ts"}}
+		})
+
+		w, r := requestMock(http.MethodGet, "/")
+		asInertiaRequest(r)
+
+		if err = i.Render(w, r, "Home"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got := w.Header().Values("Link"); len(got) != 0 {
+			t.Fatalf("links=%#v, want empty", got)
+		}
+	})
+
+	t.Run("over http", func(t *testing.T) {
+		t.Parallel()
+
+		f := tmpFile(t, viteManifest)
+
+		i, err := New(
+			rootTemplate,
+			WithVite(mustVite(t, f.Name())),
+			WithEarlyHints(EarlyHintsConfig{
+				Entries:                []string{"resources/css/app.css"},
+				InformationalResponses: true,
+			}),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := i.Render(wrappedResponseWriter{w}, r, "Home"); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}))
+		defer srv.Close()
+
+		var hints []textproto.MIMEHeader
+		ctx := httptrace.WithClientTrace(context.Background(), &httptrace.ClientTrace{
+			Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+				if code == http.StatusEarlyHints {
+					hints = append(hints, header)
+				}
+				return nil
+			},
+		})
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		resp, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		defer resp.Body.Close()
+
+		want := []string{"</build/assets/app-9a8b7c6d.css>; rel=preload; as=style"}
+
+		if len(hints) != 1 || !reflect.DeepEqual(hints[0].Values("Link"), want) {
+			t.Fatalf("early hints=%#v, want links=%#v", hints, want)
+		}
+
+		if got := resp.Header.Values("Link"); !reflect.DeepEqual(got, want) {
+			t.Fatalf("links=%#v, want=%#v", got, want)
+		}
+	})
+}
+
+func TestInertia_informationalResponseWriter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		informational bool
+		writer        func(rec *statusRecorder) http.ResponseWriter
+		want          []int
+	}{
+		{
+			name:   "not allowed by config",
+			writer: func(rec *statusRecorder) http.ResponseWriter { return wrappedResponseWriter{rec} },
+			want:   []int{http.StatusOK},
+		},
+		{
+			name:          "allowed by config",
+			informational: true,
+			writer:        func(rec *statusRecorder) http.ResponseWriter { return wrappedResponseWriter{rec} },
+			want:          []int{http.StatusEarlyHints, http.StatusOK},
+		},
+		{
+			name: "supported by writer",
+			writer: func(rec *statusRecorder) http.ResponseWriter {
+				return wrappedResponseWriter{informationalRecorder{rec, true}}
+			},
+			want: []int{http.StatusEarlyHints, http.StatusOK},
+		},
+		{
+			name:          "not supported by writer",
+			informational: true,
+			writer: func(rec *statusRecorder) http.ResponseWriter {
+				return informationalRecorder{rec, false}
+			},
+			want: []int{http.StatusOK},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := tmpFile(t, viteManifest)
+
+			i := I(func(i *Inertia) {
+				i.rootTemplateHTML = rootTemplate
+				i.vite = mustVite(t, f.Name())
+				i.earlyHints = &EarlyHintsConfig{
+					Entries:                []string{"resources/css/app.css"},
+					InformationalResponses: tt.informational,
+				}
+			})
+
+			w, r := requestMock(http.MethodGet, "/")
+			rec := &statusRecorder{ResponseRecorder: w}
+
+			if err := i.Render(tt.writer(rec), r, "Home"); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := append(rec.statuses, w.Code); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("statuses=%v, want=%v", got, tt.want)
+			}
+		})
+	}
+}
+
+// statusRecorder records informational statuses, as the net/http server response writer sends them.
+type statusRecorder struct {
+	*httptest.ResponseRecorder
+	statuses []int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if code < http.StatusOK {
+		r.statuses = append(r.statuses, code)
+		return
+	}
+
+	r.ResponseRecorder.WriteHeader(code)
+}
+
+// informationalRecorder is a middleware response writer, that reports whether it supports informational responses.
+type informationalRecorder struct {
+	http.ResponseWriter
+	supports bool
+}
+
+func (w informationalRecorder) SupportsInformationalResponses() bool {
+	return w.supports
+}
+
+func mustVite(t *testing.T, manifestPath string) *Vite {
+	t.Helper()
+
+	v, err := NewVite(manifestPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return v
+}
+
+// wrappedResponseWriter is a middleware response writer, that can be unwrapped.
+type wrappedResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w wrappedResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,383 @@
+package gonertia
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	defaultFlashCookieName    = "gonertia_flash"
+	defaultFlashCookieMaxSize = 4096
+)
+
+var errInvalidFlashCookie = errors.New("invalid flash cookie")
+
+// CookieFlashProvider is a FlashProvider, that stores flash data in the HMAC-signed
+// (and optionally AES-GCM encrypted) cookie, so no session storage is required.
+//
+// It requires Inertia middleware, because cookies are read from the request
+// and written to the response, that are stored in the request context by the middleware.
+type CookieFlashProvider struct {
+	name     string
+	path     string
+	domain   string
+	secure   bool
+	sameSite http.SameSite
+	maxSize  int
+	encrypt  bool
+
+	// The first key is used for signing and encryption,
+	// the rest of them are used only for reading (keys rotation).
+	keys []cookieFlashKey
+
+	jsonMarshaller JSONMarshaller
+}
+
+var (
+	_ FlashProvider      = (*CookieFlashProvider)(nil)
+	_ FlashPropsProvider = (*CookieFlashProvider)(nil)
+)
+
+type cookieFlashKey struct {
+	sign    []byte
+	encrypt []byte
+}
+
+// CookieFlashOption is an option parameter that modifies CookieFlashProvider.
+type CookieFlashOption func(p *CookieFlashProvider) error
+
+// NewCookieFlashProvider initializes and returns CookieFlashProvider.
+//
+// The first secret is used for signing (and encryption) of new cookies, all secrets are used
+// for verification, so you can rotate secrets by prepending the new one to the list.
+func NewCookieFlashProvider(secrets [][]byte, opts ...CookieFlashOption) (*CookieFlashProvider, error) {
+	if len(secrets) == 0 {
+		return nil, fmt.Errorf("no secrets provided")
+	}
+
+	p := &CookieFlashProvider{
+		name:           defaultFlashCookieName,
+		path:           "/",
+		sameSite:       http.SameSiteLaxMode,
+		maxSize:        defaultFlashCookieMaxSize,
+		jsonMarshaller: jsonDefaultMarshaller{},
+	}
+
+	for _, secret := range secrets {
+		if len(secret) == 0 {
+			return nil, fmt.Errorf("blank secret")
+		}
+
+		p.keys = append(p.keys, cookieFlashKey{
+			sign:    deriveKey(secret, "signing"),
+			encrypt: deriveKey(secret, "encryption"),
+		})
+	}
+
+	for _, opt := range opts {
+		if err := opt(p); err != nil {
+			return nil, fmt.Errorf("initialize cookie flash provider: %w", err)
+		}
+	}
+
+	return p, nil
+}
+
+// WithFlashCookieName returns CookieFlashOption that will set the cookie name.
+func WithFlashCookieName(name string) CookieFlashOption {
+	return func(p *CookieFlashProvider) error {
+		if name == "" {
+			return fmt.Errorf("blank cookie name")
+		}
+
+		p.name = name
+		return nil
+	}
+}
+
+// WithFlashCookiePath returns CookieFlashOption that will set the cookie path.
+func WithFlashCookiePath(path string) CookieFlashOption {
+	return func(p *CookieFlashProvider) error {
+		p.path = path
+		return nil
+	}
+}
+
+// WithFlashCookieDomain returns CookieFlashOption that will set the cookie domain.
+func WithFlashCookieDomain(domain string) CookieFlashOption {
+	return func(p *CookieFlashProvider) error {
+		p.domain = domain
+		return nil
+	}
+}
+
+// WithFlashCookieSecure returns CookieFlashOption that will set the cookie secure flag.
+func WithFlashCookieSecure(secure ...bool) CookieFlashOption {
+	return func(p *CookieFlashProvider) error {
+		p.secure = firstOr[bool](secure, true)
+		return nil
+	}
+}
+
+// WithFlashCookieSameSite returns CookieFlashOption that will set the cookie SameSite attribute.
+func WithFlashCookieSameSite(sameSite http.SameSite) CookieFlashOption {
+	return func(p *CookieFlashProvider) error {
+		p.sameSite = sameSite
+		return nil
+	}
+}
+
+// WithFlashCookieMaxSize returns CookieFlashOption that will set the max size of the cookie value.
+// Flashing data, that exceeds this size, will fail.
+func WithFlashCookieMaxSize(size int) CookieFlashOption {
+	return func(p *CookieFlashProvider) error {
+		if size < 1 {
+			return fmt.Errorf("invalid cookie max size: %d", size)
+		}
+
+		p.maxSize = size
+		return nil
+	}
+}
+
+// WithFlashCookieEncryption returns CookieFlashOption that will enable AES-GCM encryption of the cookie value.
+func WithFlashCookieEncryption() CookieFlashOption {
+	return func(p *CookieFlashProvider) error {
+		p.encrypt = true
+		return nil
+	}
+}
+
+// WithFlashCookieJSONMarshaller returns CookieFlashOption that will set the JSON marshaller.
+func WithFlashCookieJSONMarshaller(jsonMarshaller JSONMarshaller) CookieFlashOption {
+	return func(p *CookieFlashProvider) error {
+		p.jsonMarshaller = jsonMarshaller
+		return nil
+	}
+}
+
+// FlashErrors stores validation errors in the cookie.
+func (p *CookieFlashProvider) FlashErrors(ctx context.Context, errors ValidationErrors) error {
+	return p.write(ctx, p.name, errors)
+}
+
+// GetErrors returns validation errors from the cookie and then removes the cookie.
+func (p *CookieFlashProvider) GetErrors(ctx context.Context) (ValidationErrors, error) {
+	var errors ValidationErrors
+	if err := p.read(ctx, p.name, &errors); err != nil {
+		return nil, err
+	}
+
+	return errors, nil
+}
+
+// FlashProps stores props in the separate cookie, so they don't
+// share the size limit with validation errors.
+func (p *CookieFlashProvider) FlashProps(ctx context.Context, props Props) error {
+	return p.write(ctx, p.propsName(), props)
+}
+
+// GetProps returns props from the cookie and then removes the cookie.
+func (p *CookieFlashProvider) GetProps(ctx context.Context) (Props, error) {
+	var props Props
+	if err := p.read(ctx, p.propsName(), &props); err != nil {
+		return nil, err
+	}
+
+	return props, nil
+}
+
+func (p *CookieFlashProvider) propsName() string {
+	return p.name + "_props"
+}
+
+func (p *CookieFlashProvider) write(ctx context.Context, name string, data any) error {
+	w := responseWriterFromContext(ctx)
+	if w == nil {
+		return fmt.Errorf("response writer not found in context, make sure Inertia middleware is used")
+	}
+
+	payload, err := p.jsonMarshaller.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("json marshal flash data: %w", err)
+	}
+
+	value, err := p.encode(name, payload)
+	if err != nil {
+		return fmt.Errorf("encode flash cookie: %w", err)
+	}
+
+	if len(value) > p.maxSize {
+		return fmt.Errorf("flash cookie size %d exceeds max size %d", len(value), p.maxSize)
+	}
+
+	http.SetCookie(w, p.cookie(name, value, 0))
+
+	return nil
+}
+
+func (p *CookieFlashProvider) read(ctx context.Context, name string, data any) error {
+	r := requestFromContext(ctx)
+	if r == nil {
+		return fmt.Errorf("request not found in context, make sure Inertia middleware is used")
+	}
+
+	c, err := r.Cookie(name)
+	if err != nil {
+		// No flash data.
+		return nil
+	}
+
+	// Flash data must be read only once, so we have to remove the cookie.
+	if w := responseWriterFromContext(ctx); w != nil {
+		http.SetCookie(w, p.cookie(name, "", -1))
+	}
+
+	payload, err := p.decode(name, c.Value)
+	if err != nil {
+		return fmt.Errorf("decode flash cookie: %w", err)
+	}
+
+	if err = p.jsonMarshaller.Decode(bytes.NewReader(payload), data); err != nil {
+		return fmt.Errorf("json decode flash data: %w", err)
+	}
+
+	return nil
+}
+
+func (p *CookieFlashProvider) cookie(name, value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     p.path,
+		Domain:   p.domain,
+		MaxAge:   maxAge,
+		Secure:   p.secure,
+		HttpOnly: true,
+		SameSite: p.sameSite,
+	}
+}
+
+// encode returns the cookie value in format "base64(payload).base64(signature)".
+func (p *CookieFlashProvider) encode(name string, payload []byte) (string, error) {
+	key := p.keys[0]
+
+	if p.encrypt {
+		var err error
+		payload, err = encryptAESGCM(key.encrypt, payload)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	signature := base64.RawURLEncoding.EncodeToString(sign(key.sign, name, encoded))
+
+	return encoded + "." + signature, nil
+}
+
+func (p *CookieFlashProvider) decode(name, value string) ([]byte, error) {
+	encoded, signature, ok := strings.Cut(value, ".")
+	if !ok {
+		return nil, errInvalidFlashCookie
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return nil, errInvalidFlashCookie
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errInvalidFlashCookie
+	}
+
+	for _, key := range p.keys {
+		if !hmac.Equal(sig, sign(key.sign, name, encoded)) {
+			continue
+		}
+
+		if !p.encrypt {
+			return payload, nil
+		}
+
+		return decryptAESGCM(key.encrypt, payload)
+	}
+
+	return nil, errInvalidFlashCookie
+}
+
+// sign returns HMAC signature of the value, bound to the cookie name.
+func sign(key []byte, name, value string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(name))
+	mac.Write([]byte{'|'})
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
+
+// deriveKey derives 32 bytes key from the secret for the specified purpose,
+// so the same secret is never used for both signing and encryption.
+func deriveKey(secret []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+func encryptAESGCM(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func decryptAESGCM(key, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errInvalidFlashCookie
+	}
+
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errInvalidFlashCookie
+	}
+
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("new aes cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("new gcm: %w", err)
+	}
+
+	return gcm, nil
+}
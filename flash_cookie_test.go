@@ -0,0 +1,270 @@
+package gonertia
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNewCookieFlashProvider(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := NewCookieFlashProvider(
+			[][]byte{[]byte("secret1"), []byte("secret2")},
+			WithFlashCookieName("foo"),
+			WithFlashCookiePath("/bar"),
+			WithFlashCookieDomain("example.com"),
+			WithFlashCookieSecure(),
+			WithFlashCookieSameSite(http.SameSiteStrictMode),
+			WithFlashCookieMaxSize(1024),
+			WithFlashCookieEncryption(),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if len(p.keys) != 2 {
+			t.Fatalf("keys count=%d, want=%d", len(p.keys), 2)
+		}
+
+		c := p.cookie("foo", "value", 0)
+		want := &http.Cookie{
+			Name:     "foo",
+			Value:    "value",
+			Path:     "/bar",
+			Domain:   "example.com",
+			Secure:   true,
+			HttpOnly: true,
+			SameSite: http.SameSiteStrictMode,
+		}
+
+		if !reflect.DeepEqual(c, want) {
+			t.Fatalf("cookie=%#v, want=%#v", c, want)
+		}
+
+		if p.maxSize != 1024 {
+			t.Fatalf("maxSize=%d, want=%d", p.maxSize, 1024)
+		}
+
+		if !p.encrypt {
+			t.Fatal("encryption is not enabled")
+		}
+	})
+
+	t.Run("without secrets", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewCookieFlashProvider(nil)
+		if err == nil {
+			t.Fatal("error expected")
+		}
+	})
+
+	t.Run("blank secret", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewCookieFlashProvider([][]byte{[]byte("secret"), nil})
+		if err == nil {
+			t.Fatal("error expected")
+		}
+	})
+
+	t.Run("invalid option", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewCookieFlashProvider([][]byte{[]byte("secret")}, WithFlashCookieMaxSize(0))
+		if err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
+
+func TestCookieFlashProvider(t *testing.T) {
+	t.Parallel()
+
+	for name, opts := range map[string][]CookieFlashOption{
+		"redirect and read":                 nil,
+		"redirect and read with encryption": {WithFlashCookieEncryption()},
+	} {
+		opts := opts
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			i := I(func(i *Inertia) {
+				i.flash = cookieFlashProvider(t, [][]byte{[]byte("secret")}, opts...)
+			})
+
+			want := ValidationErrors{"foo": "bar"}
+
+			c := flashErrorsCookie(t, i, want)
+
+			got, w := readFlashErrors(t, i, c)
+
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("validation errors=%#v, want=%#v", got, want)
+			}
+
+			cookies := w.Result().Cookies()
+			if len(cookies) != 1 || cookies[0].Name != c.Name || cookies[0].MaxAge >= 0 {
+				t.Fatalf("flash cookie was not removed, cookies=%#v", cookies)
+			}
+		})
+	}
+
+	t.Run("flash props", func(t *testing.T) {
+		t.Parallel()
+
+		i := I(func(i *Inertia) {
+			i.flash = cookieFlashProvider(t, [][]byte{[]byte("secret")})
+		})
+
+		w, r := requestMock(http.MethodPost, "/")
+
+		i.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := SetValidationErrors(r.Context(), ValidationErrors{"foo": "bar"})
+			ctx = FlashProp(ctx, "message", "Saved!")
+			i.Redirect(w, r.WithContext(ctx), "/")
+		})).ServeHTTP(w, r)
+
+		cookies := w.Result().Cookies()
+		if len(cookies) != 2 {
+			t.Fatalf("cookies count=%d, want=%d", len(cookies), 2)
+		}
+
+		w, r = requestMock(http.MethodGet, "/")
+		for _, c := range cookies {
+			r.AddCookie(c)
+		}
+
+		var (
+			gotErrors ValidationErrors
+			gotProps  Props
+		)
+		i.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotErrors = ValidationErrorsFromContext(r.Context())
+			gotProps = PropsFromContext(r.Context())
+		})).ServeHTTP(w, r)
+
+		if want := (ValidationErrors{"foo": "bar"}); !reflect.DeepEqual(gotErrors, want) {
+			t.Fatalf("validation errors=%#v, want=%#v", gotErrors, want)
+		}
+
+		if want := (Props{"message": "Saved!"}); !reflect.DeepEqual(gotProps, want) {
+			t.Fatalf("props=%#v, want=%#v", gotProps, want)
+		}
+	})
+
+	t.Run("keys rotation", func(t *testing.T) {
+		t.Parallel()
+
+		old := I(func(i *Inertia) {
+			i.flash = cookieFlashProvider(t, [][]byte{[]byte("old")})
+		})
+
+		c := flashErrorsCookie(t, old, ValidationErrors{"foo": "bar"})
+
+		rotated := I(func(i *Inertia) {
+			i.flash = cookieFlashProvider(t, [][]byte{[]byte("new"), []byte("old")})
+		})
+
+		got, _ := readFlashErrors(t, rotated, c)
+
+		want := ValidationErrors{"foo": "bar"}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("validation errors=%#v, want=%#v", got, want)
+		}
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		t.Parallel()
+
+		i := I(func(i *Inertia) {
+			i.flash = cookieFlashProvider(t, [][]byte{[]byte("secret")})
+		})
+
+		c := flashErrorsCookie(t, i, ValidationErrors{"foo": "bar"})
+
+		other := I(func(i *Inertia) {
+			i.flash = cookieFlashProvider(t, [][]byte{[]byte("other")})
+		})
+
+		got, _ := readFlashErrors(t, other, c)
+
+		if len(got) != 0 {
+			t.Fatalf("validation errors=%#v, want empty", got)
+		}
+	})
+
+	t.Run("max size exceeded", func(t *testing.T) {
+		t.Parallel()
+
+		p := cookieFlashProvider(t, [][]byte{[]byte("secret")}, WithFlashCookieMaxSize(10))
+
+		w, r := requestMock(http.MethodGet, "/")
+		ctx := setHTTP(r.Context(), w, r)
+
+		err := p.FlashErrors(ctx, ValidationErrors{"foo": "bar"})
+		if err == nil {
+			t.Fatal("error expected")
+		}
+	})
+
+	t.Run("without middleware", func(t *testing.T) {
+		t.Parallel()
+
+		p := cookieFlashProvider(t, [][]byte{[]byte("secret")})
+
+		err := p.FlashErrors(context.Background(), ValidationErrors{"foo": "bar"})
+		if err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
+
+func cookieFlashProvider(t *testing.T, secrets [][]byte, opts ...CookieFlashOption) *CookieFlashProvider {
+	t.Helper()
+
+	p, err := NewCookieFlashProvider(secrets, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return p
+}
+
+func flashErrorsCookie(t *testing.T, i *Inertia, errors ValidationErrors) *http.Cookie {
+	t.Helper()
+
+	w, r := requestMock(http.MethodPost, "/")
+
+	i.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i.Redirect(w, r.WithContext(SetValidationErrors(r.Context(), errors)), "/")
+	})).ServeHTTP(w, r)
+
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("cookies count=%d, want=%d", len(cookies), 1)
+	}
+
+	return cookies[0]
+}
+
+func readFlashErrors(t *testing.T, i *Inertia, c *http.Cookie) (ValidationErrors, *httptest.ResponseRecorder) {
+	t.Helper()
+
+	w, r := requestMock(http.MethodGet, "/")
+	r.AddCookie(c)
+
+	var got ValidationErrors
+	i.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = ValidationErrorsFromContext(r.Context())
+	})).ServeHTTP(w, r)
+
+	return got, w
+}
@@ -0,0 +1,143 @@
+package gonertia
+
+import (
+	"html"
+	"html/template"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Head contains the page title and meta tags, that are rendered on the server side into {{ .inertiaHead }},
+// so crawlers can see them even without SSR.
+//
+// Tags are rendered with "inertia" attribute, so Inertia client replaces them by the tags from <Head> component.
+// If SSR is enabled, SSR head tags with the same key (the "inertia" attribute value,
+// or "name", "property" or "http-equiv" attribute if it is empty) take precedence.
+type Head struct {
+	Title string
+	Meta  []map[string]string
+}
+
+const (
+	titleHeadKey   = "title"
+	headKeyMetaKey = "head-key"
+)
+
+// metaKey returns the key, that identifies the meta tag.
+func metaKey(attrs map[string]string) string {
+	for _, attr := range []string{headKeyMetaKey, "name", "property", "http-equiv"} {
+		if val := attrs[attr]; val != "" {
+			return val
+		}
+	}
+	return ""
+}
+
+// headTag is a rendered head tag with its "inertia" key.
+type headTag struct {
+	key  string
+	html string
+}
+
+func (h Head) tags() []headTag {
+	tags := make([]headTag, 0, len(h.Meta)+1)
+
+	if h.Title != "" {
+		tags = append(tags, headTag{
+			key:  titleHeadKey,
+			html: "<title inertia>" + template.HTMLEscapeString(h.Title) + "</title>",
+		})
+	}
+
+	for _, attrs := range h.Meta {
+		key := metaKey(attrs)
+
+		var sb strings.Builder
+		sb.WriteString("<meta")
+		for _, name := range slices.Sorted(maps.Keys(attrs)) {
+			if name == headKeyMetaKey {
+				continue
+			}
+
+			sb.WriteString(" ")
+			sb.WriteString(template.HTMLEscapeString(name))
+			sb.WriteString(`="`)
+			sb.WriteString(template.HTMLEscapeString(attrs[name]))
+			sb.WriteString(`"`)
+		}
+		if key != "" {
+			sb.WriteString(` inertia="`)
+			sb.WriteString(template.HTMLEscapeString(key))
+			sb.WriteString(`"`)
+		}
+		sb.WriteString(">")
+
+		tags = append(tags, headTag{key: key, html: sb.String()})
+	}
+
+	return tags
+}
+
+var (
+	inertiaHeadTagRe  = regexp.MustCompile(`<([a-zA-Z]+)(\s[^>]*)?>`)
+	inertiaHeadAttrRe = regexp.MustCompile(`([^\s"'>/=]+)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+)))?`)
+)
+
+// headKeys returns the keys of the tags with "inertia" attribute from the (SSR) head html.
+//
+// Inertia <Head> renders the "inertia" attribute without value, unless "head-key" is set,
+// so the key is taken from the same attributes as the server side tags have.
+func headKeys(inertiaHead template.HTML) map[string]struct{} {
+	keys := make(map[string]struct{})
+
+	for _, m := range inertiaHeadTagRe.FindAllStringSubmatch(string(inertiaHead), -1) {
+		attrs := make(map[string]string)
+		for _, am := range inertiaHeadAttrRe.FindAllStringSubmatch(m[2], -1) {
+			attrs[strings.ToLower(am[1])] = html.UnescapeString(am[2] + am[3] + am[4])
+		}
+
+		key, ok := attrs["inertia"]
+		if !ok {
+			continue
+		}
+
+		switch {
+		case strings.EqualFold(m[1], "title"):
+			key = titleHeadKey
+		case key == "":
+			key = metaKey(attrs)
+		}
+		if key != "" {
+			keys[key] = struct{}{}
+		}
+	}
+
+	return keys
+}
+
+// mergeHead prepends the server side head tags to the (SSR) head html,
+// skipping the tags, which keys are already present in it.
+func mergeHead(head Head, inertiaHead template.HTML) template.HTML {
+	tags := head.tags()
+	if len(tags) == 0 {
+		return inertiaHead
+	}
+
+	existing := headKeys(inertiaHead)
+
+	result := make([]string, 0, len(tags)+1)
+	for _, tag := range tags {
+		if _, ok := existing[tag.key]; ok && tag.key != "" {
+			continue
+		}
+		result = append(result, tag.html)
+	}
+
+	if inertiaHead != "" {
+		result = append(result, string(inertiaHead))
+	}
+
+	return template.HTML(strings.Join(result, "\n"))
+}
@@ -0,0 +1,144 @@
+package gonertia
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"testing"
+)
+
+func Test_mergeHead(t *testing.T) {
+	t.Parallel()
+
+	head := Head{
+		Title: "Foo & Bar",
+		Meta: []map[string]string{
+			{"name": "description", "content": "Server <description>"},
+			{"property": "og:title", "content": "Foo"},
+			{"head-key": "viewport", "name": "viewport", "content": "width=device-width"},
+			{"charset": "utf-8"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		head        Head
+		inertiaHead template.HTML
+		want        template.HTML
+	}{
+		{
+			"empty head",
+			Head{},
+			`<title inertia>SSR</title>`,
+			`<title inertia>SSR</title>`,
+		},
+		{
+			"without ssr",
+			head,
+			"",
+			"<title inertia>Foo &amp; Bar</title>\n" +
+				`<meta content="Server &lt;description&gt;" name="description" inertia="description">` + "\n" +
+				`<meta content="Foo" property="og:title" inertia="og:title">` + "\n" +
+				`<meta content="width=device-width" name="viewport" inertia="viewport">` + "\n" +
+				`<meta charset="utf-8">`,
+		},
+		{
+			"with ssr",
+			head,
+			"<title inertia>SSR</title>\n" + `<meta name="description" content="SSR" inertia="description">`,
+			`<meta content="Foo" property="og:title" inertia="og:title">` + "\n" +
+				`<meta content="width=device-width" name="viewport" inertia="viewport">` + "\n" +
+				`<meta charset="utf-8">` + "\n" +
+				"<title inertia>SSR</title>\n" + `<meta name="description" content="SSR" inertia="description">`,
+		},
+		{
+			"with keyless ssr tags",
+			head,
+			`<meta name="description" content="SSR" inertia>` + "\n" +
+				`<meta property="og:title" content="SSR" inertia="" />` + "\n" +
+				`<meta name="viewport" content="SSR">`,
+			"<title inertia>Foo &amp; Bar</title>\n" +
+				`<meta content="width=device-width" name="viewport" inertia="viewport">` + "\n" +
+				`<meta charset="utf-8">` + "\n" +
+				`<meta name="description" content="SSR" inertia>` + "\n" +
+				`<meta property="og:title" content="SSR" inertia="" />` + "\n" +
+				`<meta name="viewport" content="SSR">`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := mergeHead(tt.head, tt.inertiaHead); got != tt.want {
+				t.Fatalf("got=%s, want=%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInertia_Render_head(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without ssr", func(t *testing.T) {
+		t.Parallel()
+
+		i := I(func(i *Inertia) {
+			i.rootTemplateHTML = `{{ .inertiaHead }}`
+		})
+
+		w, r := requestMock(http.MethodGet, "/")
+
+		ctx := SetTitle(r.Context(), "Foo")
+		ctx = AddMeta(ctx, map[string]string{"name": "description", "content": "Bar"})
+
+		if err := i.Render(w, r.WithContext(ctx), "Some/Component"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := "<title inertia>Foo</title>\n" + `<meta content="Bar" name="description" inertia="description">`
+		if got := w.Body.String(); got != want {
+			t.Fatalf("got=%s, want=%s", got, want)
+		}
+	})
+
+	t.Run("with ssr", func(t *testing.T) {
+		t.Parallel()
+
+		i := I(func(i *Inertia) {
+			i.rootTemplateHTML = `{{ .inertiaHead }}`
+			i.ssr = &ssrRendererMock{head: []string{`<title inertia>SSR</title>`}}
+		})
+
+		w, r := requestMock(http.MethodGet, "/")
+
+		ctx := SetTitle(r.Context(), "Foo")
+		ctx = AddMeta(ctx, map[string]string{"name": "description", "content": "Bar"})
+
+		if err := i.Render(w, r.WithContext(ctx), "Some/Component"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := `<meta content="Bar" name="description" inertia="description">` + "\n<title inertia>SSR</title>"
+		if got := w.Body.String(); got != want {
+			t.Fatalf("got=%s, want=%s", got, want)
+		}
+	})
+
+	t.Run("inertia request", func(t *testing.T) {
+		t.Parallel()
+
+		i := I()
+
+		w, r := requestMock(http.MethodGet, "/")
+		asInertiaRequest(r)
+
+		if err := i.Render(w, r.WithContext(SetTitle(context.Background(), "Foo")), "Some/Component"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		assertInertiaResponse(t, w)
+	})
+}
@@ -46,6 +46,14 @@ func withExcept(r *http.Request, data []string) {
 	r.Header.Set("X-Inertia-Partial-Except", strings.Join(data, ","))
 }
 
+func withReset(r *http.Request, data []string) {
+	r.Header.Set("X-Inertia-Reset", strings.Join(data, ","))
+}
+
+func withErrorBag(r *http.Request, errorBag string) {
+	r.Header.Set("X-Inertia-Error-Bag", errorBag)
+}
+
 func withPartialComponent(r *http.Request, component string) {
 	r.Header.Set("X-Inertia-Partial-Component", component)
 }
@@ -191,6 +199,7 @@ func tmpFile(t *testing.T, content string) *os.File {
 
 type flashProviderMock struct {
 	errors ValidationErrors
+	props  Props
 }
 
 func (p *flashProviderMock) FlashErrors(_ context.Context, errors ValidationErrors) error {
@@ -201,3 +210,24 @@ func (p *flashProviderMock) FlashErrors(_ context.Context, errors ValidationErro
 func (p *flashProviderMock) GetErrors(_ context.Context) (ValidationErrors, error) {
 	return p.errors, nil
 }
+
+func (p *flashProviderMock) FlashProps(_ context.Context, props Props) error {
+	p.props = props
+	return nil
+}
+
+func (p *flashProviderMock) GetProps(_ context.Context) (Props, error) {
+	return p.props, nil
+}
+
+type ssrRendererMock struct {
+	head     []string
+	body     string
+	err      error
+	pageJSON []byte
+}
+
+func (m *ssrRendererMock) Render(_ context.Context, pageJSON []byte) ([]string, string, error) {
+	m.pageJSON = pageJSON
+	return m.head, m.body, m.err
+}
@@ -11,6 +11,8 @@ const (
 	headerInertiaPartialData      = "X-Inertia-Partial-Data"
 	headerInertiaPartialExcept    = "X-Inertia-Partial-Except"
 	headerInertiaPartialComponent = "X-Inertia-Partial-Component"
+	headerInertiaReset            = "X-Inertia-Reset"
+	headerInertiaErrorBag         = "X-Inertia-Error-Bag"
 	headerInertiaVersion          = "X-Inertia-Version"
 	headerVary                    = "Vary"
 	headerContentType             = "Content-Type"
@@ -63,6 +65,19 @@ func exceptFromRequest(r *http.Request) []string {
 	return strings.Split(header, ",")
 }
 
+func resetFromRequest(r *http.Request) []string {
+	header := r.Header.Get(headerInertiaReset)
+	if header == "" {
+		return nil
+	}
+
+	return strings.Split(header, ",")
+}
+
+func errorBagFromRequest(r *http.Request) string {
+	return r.Header.Get(headerInertiaErrorBag)
+}
+
 func partialComponentFromRequest(r *http.Request) string {
 	return r.Header.Get(headerInertiaPartialComponent)
 }
@@ -5,29 +5,62 @@ import (
 	"fmt"
 	"html/template"
 	"io"
+	"io/fs"
 	"log"
-	"net/http"
+	"maps"
 	"os"
+	"path"
+	"sync"
+	"time"
 )
 
 // Inertia is a main Gonertia structure, which contains all the logic for being an Inertia adapter.
 type Inertia struct {
-	rootTemplate     *template.Template
-	rootTemplateHTML string
+	rootTemplateMu      sync.Mutex
+	rootTemplates       map[string]*template.Template
+	rootTemplateHTML    string
+	rootTemplatePath    string
+	rootTemplateModTime time.Time
 
+	rootTemplateFS       fs.FS
+	rootTemplatePatterns []string
+	rootTemplateName     string
+
+	namedRootTemplatesHTML map[string]string
+	rootTemplateRules      []rootTemplateRule
+
+	sharedMu            sync.RWMutex
 	sharedProps         Props
 	sharedTemplateData  TemplateData
 	sharedTemplateFuncs TemplateFuncs
 
 	flash FlashProvider
 
-	ssrURL        string
-	ssrHTTPClient *http.Client
-
-	containerID    string
-	version        string
-	jsonMarshaller JSONMarshaller
-	logger         Logger
+	vite       *Vite
+	earlyHints *EarlyHintsConfig
+
+	ssr          SSRRenderer
+	ssrConfig    SSRConfig
+	ssrBreaker   *ssrCircuitBreaker
+	ssrStats     ssrStats
+	ssrOnly      []string
+	ssrExcept    []string
+	ssrCache     SSRCache
+	ssrCacheOnly []string
+
+	containerID string
+	version     string
+	devMode     bool
+	streaming   bool
+	cspPolicy   string
+
+	pageScriptElement bool
+	encryptHistory    bool
+	jsonMarshaller    JSONMarshaller
+	logger            Logger
+
+	propResolutionLimit   int
+	propResolutionTimeout time.Duration
 }
 
 // New initializes and returns Inertia.
@@ -36,8 +69,39 @@ func New(rootTemplateHTML string, opts ...Option) (*Inertia, error) {
 		return nil, fmt.Errorf("blank root template")
 	}
 
+	return newInertia(func(i *Inertia) {
+		i.rootTemplateHTML = rootTemplateHTML
+	}, opts...)
+}
+
+// NewFromFS parses the root template set from the file system (e.g. embed.FS), so the root
+// template can use partials and layouts from other files (e.g. {{ template "head" . }}),
+// and then initializes Inertia.
+//
+// Patterns are the same as in template.ParseFS. The root template is the first matched file,
+// use WithRootTemplateName to choose another one.
+func NewFromFS(fsys fs.FS, patterns []string, opts ...Option) (*Inertia, error) {
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("no root template patterns")
+	}
+
+	matches, err := fs.Glob(fsys, patterns[0])
+	if err != nil {
+		return nil, fmt.Errorf("match root template pattern %q: %w", patterns[0], err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("pattern %q matches no files", patterns[0])
+	}
+
+	return newInertia(func(i *Inertia) {
+		i.rootTemplateFS = fsys
+		i.rootTemplatePatterns = patterns
+		i.rootTemplateName = path.Base(matches[0])
+	}, opts...)
+}
+
+func newInertia(init func(i *Inertia), opts ...Option) (*Inertia, error) {
 	i := &Inertia{
-		rootTemplateHTML:    rootTemplateHTML,
 		jsonMarshaller:      jsonDefaultMarshaller{},
 		containerID:         "app",
 		logger:              log.New(io.Discard, "", 0),
@@ -46,23 +110,48 @@ func New(rootTemplateHTML string, opts ...Option) (*Inertia, error) {
 		sharedTemplateFuncs: make(TemplateFuncs),
 	}
 
+	init(i)
+
 	for _, opt := range opts {
 		if err := opt(i); err != nil {
 			return nil, fmt.Errorf("initialize inertia: %w", err)
 		}
 	}
 
+	if err := i.validateRootTemplates(); err != nil {
+		return nil, fmt.Errorf("initialize inertia: %w", err)
+	}
+
+	if i.earlyHints != nil && i.vite == nil {
+		return nil, fmt.Errorf("initialize inertia: early hints require vite integration")
+	}
+
 	return i, nil
 }
 
 // NewFromFile reads all bytes from the root template file and then initializes Inertia.
+//
+// In development mode (see WithDevMode), the file is re-read when it's modified.
 func NewFromFile(rootTemplatePath string, opts ...Option) (*Inertia, error) {
+	info, err := os.Stat(rootTemplatePath)
+	if err != nil {
+		return nil, fmt.Errorf("stat file %q: %w", rootTemplatePath, err)
+	}
+
 	bs, err := os.ReadFile(rootTemplatePath)
 	if err != nil {
 		return nil, fmt.Errorf("read file %q: %w", rootTemplatePath, err)
 	}
 
-	return NewFromBytes(bs, opts...)
+	i, err := NewFromBytes(bs, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	i.rootTemplatePath = rootTemplatePath
+	i.rootTemplateModTime = info.ModTime()
+
+	return i, nil
 }
 
 // NewFromReader reads all bytes from the reader with root template html and then initializes Inertia.
@@ -95,28 +184,56 @@ type FlashProvider interface {
 	GetErrors(ctx context.Context) (ValidationErrors, error)
 }
 
+// FlashPropsProvider defines an optional interface for flash data provider,
+// that can also flash arbitrary props (success messages, etc.) to the next request.
+type FlashPropsProvider interface {
+	FlashProps(ctx context.Context, props Props) error
+	GetProps(ctx context.Context) (Props, error)
+}
+
 // ShareProp adds passed prop to shared props.
+// It's safe to call it concurrently with rendering.
 func (i *Inertia) ShareProp(key string, val any) {
+	i.sharedMu.Lock()
+	defer i.sharedMu.Unlock()
+
 	i.sharedProps[key] = val
 }
 
-// SharedProps returns shared props.
+// SharedProps returns a copy of shared props.
 func (i *Inertia) SharedProps() Props {
-	return i.sharedProps
+	i.sharedMu.RLock()
+	defer i.sharedMu.RUnlock()
+
+	return maps.Clone(i.sharedProps)
 }
 
 // SharedProp return the shared prop.
 func (i *Inertia) SharedProp(key string) (any, bool) {
+	i.sharedMu.RLock()
+	defer i.sharedMu.RUnlock()
+
 	val, ok := i.sharedProps[key]
 	return val, ok
 }
 
 // ShareTemplateData adds passed data to shared template data.
+// It's safe to call it concurrently with rendering.
 func (i *Inertia) ShareTemplateData(key string, val any) {
+	i.sharedMu.Lock()
+	defer i.sharedMu.Unlock()
+
 	i.sharedTemplateData[key] = val
 }
 
 // ShareTemplateFunc adds passed value to the shared template func map.
+// Root templates will be parsed again with the new func map on the next render.
 func (i *Inertia) ShareTemplateFunc(key string, val any) {
+	i.sharedMu.Lock()
 	i.sharedTemplateFuncs[key] = val
+	i.sharedMu.Unlock()
+
+	i.rootTemplateMu.Lock()
+	clear(i.rootTemplates)
+	i.rootTemplateMu.Unlock()
 }
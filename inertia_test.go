@@ -1,9 +1,13 @@
 package gonertia
 
 import (
+	"fmt"
+	"net/http"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
 )
 
 var rootTemplate = `<html>
@@ -35,6 +39,42 @@ func TestNew(t *testing.T) {
 			t.Fatal("error expected")
 		}
 	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(`{{ if .foo }}`)
+		if err == nil {
+			t.Fatal("error expected")
+		}
+	})
+
+	t.Run("invalid named template", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(rootTemplate, WithRootTemplate("admin", `{{ end }}`))
+		if err == nil {
+			t.Fatal("error expected")
+		}
+	})
+
+	t.Run("early hints without vite", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(rootTemplate, WithEarlyHints(EarlyHintsConfig{Entries: []string{"resources/js/app.ts"}}))
+		if err == nil {
+			t.Fatal("error expected")
+		}
+	})
+
+	t.Run("template func shared later", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(`{{ trim " foo " }}`)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
 }
 
 func TestNewFromFile(t *testing.T) {
@@ -50,6 +90,10 @@ func TestNewFromFile(t *testing.T) {
 	if i.rootTemplateHTML != rootTemplate {
 		t.Fatalf("root template html=%s, want=%s", i.rootTemplateHTML, rootTemplate)
 	}
+
+	if i.rootTemplatePath != f.Name() {
+		t.Fatalf("root template path=%s, want=%s", i.rootTemplatePath, f.Name())
+	}
 }
 
 func TestNewFromReader(t *testing.T) {
@@ -78,6 +122,94 @@ func TestNewFromBytes(t *testing.T) {
 	}
 }
 
+func TestNewFromFS(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"views/root.html":           {Data: []byte(`<html>{{ template "head" . }}<body>{{ .inertia }}</body></html>`)},
+		"views/admin.html":          {Data: []byte(`<html>{{ template "head" . }}<body class="admin">{{ .inertia }}</body></html>`)},
+		"views/partials/head.html":  {Data: []byte(`{{ define "head" }}<head>{{ upper "title" }}</head>{{ end }}`)},
+		"views/partials/empty.html": {Data: []byte(``)},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		i, err := NewFromFS(fsys, []string{"views/root.html", "views/partials/*.html"})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		i.ShareTemplateFunc("upper", strings.ToUpper)
+
+		got := renderRootTemplate(t, i)
+		want := `<html><head>TITLE</head><body><div id="app" data-page=`
+		if !strings.HasPrefix(got, want) {
+			t.Fatalf("got=%s, want prefix=%s", got, want)
+		}
+	})
+
+	t.Run("with root template name", func(t *testing.T) {
+		t.Parallel()
+
+		i, err := NewFromFS(
+			fsys,
+			[]string{"views/*.html", "views/partials/*.html"},
+			WithRootTemplateName("admin.html"),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		i.ShareTemplateFunc("upper", strings.ToUpper)
+
+		got := renderRootTemplate(t, i)
+		want := `<html><head>TITLE</head><body class="admin">`
+		if !strings.HasPrefix(got, want) {
+			t.Fatalf("got=%s, want prefix=%s", got, want)
+		}
+	})
+
+	t.Run("unknown root template name", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewFromFS(fsys, []string{"views/*.html"}, WithRootTemplateName("unknown.html"))
+		if err == nil {
+			t.Fatal("error expected")
+		}
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewFromFS(fstest.MapFS{
+			"root.html":  {Data: []byte(rootTemplate)},
+			"other.html": {Data: []byte(`{{ range }}`)},
+		}, []string{"*.html"})
+		if err == nil {
+			t.Fatal("error expected")
+		}
+	})
+
+	t.Run("no patterns", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewFromFS(fsys, nil)
+		if err == nil {
+			t.Fatal("error expected")
+		}
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewFromFS(fsys, []string{"templates/*.html"})
+		if err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
+
 func TestInertia_ShareProp(t *testing.T) {
 	t.Parallel()
 
@@ -331,3 +463,62 @@ func TestInertia_ShareTemplateFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestInertia_ShareTemplateFunc_reparse(t *testing.T) {
+	t.Parallel()
+
+	i, err := New(`{{ foo }}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	i.ShareTemplateFunc("foo", func() string { return "bar" })
+
+	if got := renderRootTemplate(t, i); got != "bar" {
+		t.Fatalf("got=%s, want=%s", got, "bar")
+	}
+
+	i.ShareTemplateFunc("foo", func() string { return "baz" })
+
+	if got := renderRootTemplate(t, i); got != "baz" {
+		t.Fatalf("got=%s, want=%s", got, "baz")
+	}
+}
+
+func TestInertia_concurrentUse(t *testing.T) {
+	t.Parallel()
+
+	i, err := New(`{{ .foo }}{{ upper "foo" }}{{ .inertia }}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	i.ShareTemplateFunc("upper", strings.ToUpper)
+
+	var wg sync.WaitGroup
+
+	for n := range 10 {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			w, r := requestMock(http.MethodGet, "/")
+			if err := i.Render(w, r, "Some/Component"); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			key := fmt.Sprintf("key%d", n)
+			i.ShareProp(key, n)
+			i.ShareTemplateData(key, n)
+			i.ShareTemplateFunc("upper", strings.ToUpper)
+			_ = i.SharedProps()
+		}()
+	}
+
+	wg.Wait()
+}
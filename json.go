@@ -20,3 +20,17 @@ func (j jsonDefaultMarshaller) Decode(r io.Reader, v interface{}) error {
 func (j jsonDefaultMarshaller) Marshal(v interface{}) ([]byte, error) {
 	return json.Marshal(v)
 }
+
+// inertiaJSONMarshaller is JSONMarshaller, that delegates to the Inertia's marshaller,
+// so it doesn't depend on the order of options.
+type inertiaJSONMarshaller struct {
+	i *Inertia
+}
+
+func (j inertiaJSONMarshaller) Decode(r io.Reader, v interface{}) error {
+	return j.i.jsonMarshaller.Decode(r, v)
+}
+
+func (j inertiaJSONMarshaller) Marshal(v interface{}) ([]byte, error) {
+	return j.i.jsonMarshaller.Marshal(v)
+}
@@ -17,8 +17,13 @@ func (i *Inertia) Middleware(next http.Handler) http.Handler {
 		// https://github.com/inertiajs/inertia-laravel/pull/404
 		setInertiaVaryInResponse(w)
 
-		// Resolve validation errors from the flash data provider.
+		// Put response writer and request to the context,
+		// so flash data providers can work with cookies.
+		r = r.WithContext(setHTTP(r.Context(), w, r))
+
+		// Resolve validation errors and props from the flash data provider.
 		r = i.resolveValidationErrors(r)
+		r = i.resolveFlashProps(r)
 
 		if !IsInertiaRequest(r) {
 			next.ServeHTTP(w, r)
@@ -87,6 +92,37 @@ func (i *Inertia) resolveValidationErrors(r *http.Request) *http.Request {
 	return r.WithContext(SetValidationErrors(r.Context(), validationErrors))
 }
 
+func (i *Inertia) resolveFlashProps(r *http.Request) *http.Request {
+	flashProps, ok := i.flash.(FlashPropsProvider)
+	if !ok {
+		return r
+	}
+
+	props, err := flashProps.GetProps(r.Context())
+	if err != nil {
+		i.logger.Printf("get props from flash data provider error: %s", err)
+		return r
+	}
+
+	if len(props) == 0 {
+		return r
+	}
+
+	ctx := r.Context()
+	for key, val := range props {
+		if key == clearHistoryFlashKey {
+			if clearHistory, _ := val.(bool); clearHistory {
+				ctx = ClearHistory(ctx)
+			}
+			continue
+		}
+
+		ctx = SetProp(ctx, key, val)
+	}
+
+	return r.WithContext(ctx)
+}
+
 func (i *Inertia) copyWrapperResponse(dst http.ResponseWriter, src *inertiaResponseWrapper) {
 	i.copyWrapperHeaders(dst, src)
 	i.copyWrapperStatusCode(dst, src)
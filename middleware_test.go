@@ -51,6 +51,33 @@ func TestInertia_Middleware(t *testing.T) {
 				t.Fatalf("validation errors=%#v, want=%#v", got, want)
 			}
 		})
+
+		t.Run("resolve props from flash data provider", func(t *testing.T) {
+			t.Parallel()
+
+			w, r := requestMock(http.MethodGet, "/")
+
+			want := Props{
+				"message": "Saved!",
+			}
+
+			flashProvider := &flashProviderMock{
+				props: want,
+			}
+
+			i := I(func(i *Inertia) {
+				i.flash = flashProvider
+			})
+
+			var got Props
+			i.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = PropsFromContext(r.Context())
+			})).ServeHTTP(w, r)
+
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("props=%#v, want=%#v", got, want)
+			}
+		})
 	})
 
 	t.Run("inertia request", func(t *testing.T) {
@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"net/http"
+	"time"
 )
 
 // Option is an option parameter that modifies Inertia.
@@ -29,6 +29,150 @@ func WithVersionFromFile(path string) Option {
 	}
 }
 
+// WithVite returns Option that will enable Vite integration: adds "vite" template func
+// (e.g. {{ vite "resources/js/app.ts" }}), that renders the entry tags,
+// and sets Inertia's version based on the build manifest checksum.
+func WithVite(vite *Vite) Option {
+	return func(i *Inertia) error {
+		if vite == nil {
+			return fmt.Errorf("nil vite")
+		}
+
+		i.vite = vite
+		i.ShareTemplateFunc("vite", vite.Tags)
+
+		if vite.Version() != "" {
+			i.version = vite.Version()
+		}
+		return nil
+	}
+}
+
+// WithRootTemplateName returns Option that will set the name of the root template
+// in the template set, parsed by NewFromFS.
+func WithRootTemplateName(name string) Option {
+	return func(i *Inertia) error {
+		if i.rootTemplateFS == nil {
+			return fmt.Errorf("root template name can be set only for templates from fs")
+		}
+		if name == "" {
+			return fmt.Errorf("blank root template name")
+		}
+
+		i.rootTemplateName = name
+		return nil
+	}
+}
+
+// WithRootTemplate returns Option that will register an additional named root template,
+// that can be selected using WithRootTemplateFor or SetRootTemplate.
+func WithRootTemplate(name, rootTemplateHTML string) Option {
+	return func(i *Inertia) error {
+		if name == "" {
+			return fmt.Errorf("blank root template name")
+		}
+		if rootTemplateHTML == "" {
+			return fmt.Errorf("blank root template")
+		}
+
+		if i.namedRootTemplatesHTML == nil {
+			i.namedRootTemplatesHTML = make(map[string]string)
+		}
+		i.namedRootTemplatesHTML[name] = rootTemplateHTML
+		return nil
+	}
+}
+
+// WithRootTemplateFor returns Option that will use the named root template for the passed components.
+// Component can be specified by name or by prefix with trailing "*" (e.g. "Admin/*").
+//
+// Root template is either registered by WithRootTemplate or is a template from the set, parsed by NewFromFS.
+func WithRootTemplateFor(name string, components ...string) Option {
+	return func(i *Inertia) error {
+		if name == "" {
+			return fmt.Errorf("blank root template name")
+		}
+		if len(components) == 0 {
+			return fmt.Errorf("no root template components provided")
+		}
+
+		i.rootTemplateRules = append(i.rootTemplateRules, rootTemplateRule{
+			name:       name,
+			components: components,
+		})
+		return nil
+	}
+}
+
+// WithDevMode returns Option that will enable Inertia's development mode:
+// the root template file (see NewFromFile) is re-read and re-parsed when it's modified,
+// and templates from fs (see NewFromFS) are re-parsed on every render,
+// so there is no need to restart the server after editing them.
+func WithDevMode(devMode ...bool) Option {
+	return func(i *Inertia) error {
+		i.devMode = firstOr[bool](devMode, true)
+		return nil
+	}
+}
+
+// WithStreaming returns Option that will enable streaming of HTML responses: the part of the root template
+// before {{ .inertiaHead }} (or {{ .inertia }}) is flushed to the client before props are resolved
+// and the page is rendered by SSR, so the browser can start fetching assets earlier.
+//
+// Note that the response status and headers are sent before props resolution,
+// so the errors, that happen after that, can't change the response.
+func WithStreaming(streaming ...bool) Option {
+	return func(i *Inertia) error {
+		i.streaming = firstOr[bool](streaming, true)
+		return nil
+	}
+}
+
+// WithEarlyHints returns Option that will send 103 Early Hints with Link preload headers
+// of the Vite entries and the page component chunk for full page (non-Inertia) visits.
+// It requires Vite integration (see WithVite), hints are not sent in Vite development mode.
+//
+// 103 is sent only if the response writer supports it (see EarlyHintsConfig.InformationalResponses),
+// otherwise only Link headers are added to the final response.
+func WithEarlyHints(config EarlyHintsConfig) Option {
+	return func(i *Inertia) error {
+		if len(config.Entries) == 0 && config.ComponentChunk == nil {
+			return fmt.Errorf("no early hints entries provided")
+		}
+
+		i.earlyHints = &config
+		return nil
+	}
+}
+
+// WithCSP returns Option that will set Content-Security-Policy header for full page visits.
+// Every {nonce} placeholder in the policy is replaced by the nonce, generated per request
+// (e.g. "script-src 'self' 'nonce-{nonce}'; style-src 'self' 'nonce-{nonce}'").
+//
+// The nonce is available in the root template as {{ .cspNonce }}, and is added to the SSR head tags.
+func WithCSP(policy string) Option {
+	return func(i *Inertia) error {
+		if policy == "" {
+			return fmt.Errorf("blank content security policy")
+		}
+
+		i.cspPolicy = policy
+		return nil
+	}
+}
+
+// WithPageScriptElement returns Option that will put the page into
+// <script data-page="app" type="application/json"> element instead of data-page attribute of the container,
+// which is smaller and faster to parse for large pages.
+//
+// Inertia client must be configured to read the initial page from the script element.
+func WithPageScriptElement(pageScriptElement ...bool) Option {
+	return func(i *Inertia) error {
+		i.pageScriptElement = firstOr[bool](pageScriptElement, true)
+		return nil
+	}
+}
+
 // WithJSONMarshaller returns Option that will set Inertia's JSON marshaller.
 func WithJSONMarshaller(jsonMarshaller JSONMarshaller) Option {
 	return func(i *Inertia) error {
@@ -65,18 +209,109 @@ func WithContainerID(id string) Option {
 }
 
 // WithSSR returns Option that will enable server side rendering on Inertia.
+// SSR requests time out after 5 seconds, use WithSSRConfig to change the timeout.
 func WithSSR(url ...string) Option {
 	return func(i *Inertia) error {
-		var u string
-		if len(url) > 0 {
-			u = url[0]
-		} else {
-			const defaultURL = "http://127.0.0.1:13714"
-			u = defaultURL
+		u := firstOr[string](url, defaultSSRURL)
+
+		i.ssr = i.newHTTPSSRRenderer(u)
+		return nil
+	}
+}
+
+// WithSSRRenderer returns Option that will enable server side rendering on Inertia
+// using the passed SSR renderer (for example, a fake renderer in tests).
+func WithSSRRenderer(renderer SSRRenderer) Option {
+	return func(i *Inertia) error {
+		i.ssr = renderer
+		return nil
+	}
+}
+
+// WithSSRProcess returns Option that will enable server side rendering on Inertia
+// using the SSR server, supervised by the passed process.
+//
+// The process must be started separately using SSRProcess.Start.
+func WithSSRProcess(process *SSRProcess) Option {
+	return func(i *Inertia) error {
+		if process == nil {
+			return fmt.Errorf("nil ssr process")
+		}
+
+		i.ssr = i.newHTTPSSRRenderer(process.URL())
+		return nil
+	}
+}
+
+// WithSSROnly returns Option that will enable server side rendering only for the passed components.
+// Component can be specified by name or by prefix with trailing "*" (e.g. "Public/*").
+func WithSSROnly(components ...string) Option {
+	return func(i *Inertia) error {
+		if len(components) == 0 {
+			return fmt.Errorf("no ssr components provided")
+		}
+
+		i.ssrOnly = append(i.ssrOnly, components...)
+		return nil
+	}
+}
+
+// WithSSRExcept returns Option that will disable server side rendering for the passed components.
+// Component can be specified by name or by prefix with trailing "*" (e.g. "Admin/*").
+func WithSSRExcept(components ...string) Option {
+	return func(i *Inertia) error {
+		if len(components) == 0 {
+			return fmt.Errorf("no ssr components provided")
+		}
+
+		i.ssrExcept = append(i.ssrExcept, components...)
+		return nil
+	}
+}
+
+// WithSSRCache returns Option that will cache server side rendered pages.
+// See NewMemorySSRCache for the default in-memory implementation.
+//
+// Only pages, that are identical for all visitors, should be cached. If components are passed,
+// only these pages are cached. Component can be specified by name or by prefix with trailing "*" (e.g. "Public/*").
+// Caching can also be disabled for the single request by DisableSSRCache.
+func WithSSRCache(cache SSRCache, components ...string) Option {
+	return func(i *Inertia) error {
+		if cache == nil {
+			return fmt.Errorf("nil ssr cache")
+		}
+
+		i.ssrCache = cache
+		i.ssrCacheOnly = components
+		return nil
+	}
+}
+
+// WithSSRConfig returns Option that will set Inertia's server side rendering
+// timeout, retries and circuit breaker configuration.
+func WithSSRConfig(config SSRConfig) Option {
+	return func(i *Inertia) error {
+		if config.Timeout < 0 {
+			return fmt.Errorf("invalid ssr timeout: %s", config.Timeout)
+		}
+		if config.Retries < 0 {
+			return fmt.Errorf("invalid ssr retries: %d", config.Retries)
+		}
+		if config.FailureThreshold < 0 {
+			return fmt.Errorf("invalid ssr failure threshold: %d", config.FailureThreshold)
+		}
+
+		i.ssrConfig = config
+		i.ssrBreaker = nil
+
+		if config.FailureThreshold > 0 {
+			if config.CoolDown <= 0 {
+				return fmt.Errorf("invalid ssr cool down: %s", config.CoolDown)
+			}
+
+			i.ssrBreaker = newSSRCircuitBreaker(config.FailureThreshold, config.CoolDown)
 		}
 
-		i.ssrURL = u
-		i.ssrHTTPClient = &http.Client{}
 		return nil
 	}
 }
@@ -88,3 +323,39 @@ func WithFlashProvider(flashData FlashProvider) Option {
 		return nil
 	}
 }
+
+// WithEncryptHistory returns Option that will enable Inertia's global history encryption.
+//
+// https://v2.inertiajs.com/history-encryption
+func WithEncryptHistory(encryptHistory ...bool) Option {
+	return func(i *Inertia) error {
+		i.encryptHistory = firstOr[bool](encryptHistory, true)
+		return nil
+	}
+}
+
+// WithConcurrentPropResolution returns Option that will enable concurrent resolution
+// of props values (closures, Proper and TryProper), using not more than limit goroutines per render.
+func WithConcurrentPropResolution(limit int) Option {
+	return func(i *Inertia) error {
+		if limit < 1 {
+			return fmt.Errorf("invalid concurrent prop resolution limit: %d", limit)
+		}
+
+		i.propResolutionLimit = limit
+		return nil
+	}
+}
+
+// WithPropResolutionTimeout returns Option that will set the timeout of every prop value resolution.
+// The timeout is observed by context-aware props: ContextProper and func(context.Context) (any, error).
+func WithPropResolutionTimeout(timeout time.Duration) Option {
+	return func(i *Inertia) error {
+		if timeout <= 0 {
+			return fmt.Errorf("invalid prop resolution timeout: %s", timeout)
+		}
+
+		i.propResolutionTimeout = timeout
+		return nil
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"log"
 	"reflect"
 	"testing"
+	"testing/fstest"
+	"time"
 )
 
 func TestWithVersion(t *testing.T) {
@@ -46,6 +48,253 @@ func TestWithVersionFromFile(t *testing.T) {
 	}
 }
 
+func TestWithVite(t *testing.T) {
+	t.Parallel()
+
+	t.Run("positive", func(t *testing.T) {
+		t.Parallel()
+
+		i := I(func(i *Inertia) {
+			i.sharedTemplateFuncs = make(TemplateFuncs)
+		})
+
+		f := tmpFile(t, viteManifest)
+
+		vite, err := NewVite(f.Name())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		option := WithVite(vite)
+
+		if err = option(i); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if i.vite != vite {
+			t.Fatalf("vite=%v, want=%v", i.vite, vite)
+		}
+
+		if i.version != vite.Version() {
+			t.Fatalf("version=%s, want=%s", i.version, vite.Version())
+		}
+
+		if _, ok := i.sharedTemplateFuncs["vite"]; !ok {
+			t.Fatal("vite template func is not shared")
+		}
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := New(rootTemplate, WithVite(nil)); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
+
+func TestWithRootTemplateName(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		i := I(func(i *Inertia) {
+			i.rootTemplateFS = fstest.MapFS{}
+		})
+
+		if err := WithRootTemplateName("admin.html")(i); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if i.rootTemplateName != "admin.html" {
+			t.Fatalf("root template name=%s, want=%s", i.rootTemplateName, "admin.html")
+		}
+	})
+
+	t.Run("without fs", func(t *testing.T) {
+		t.Parallel()
+
+		if err := WithRootTemplateName("admin.html")(I()); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
+
+func TestWithRootTemplate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		i := I()
+
+		if err := WithRootTemplate("admin", "foo")(i); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := map[string]string{"admin": "foo"}
+		if !reflect.DeepEqual(i.namedRootTemplatesHTML, want) {
+			t.Fatalf("named root templates=%#v, want=%#v", i.namedRootTemplatesHTML, want)
+		}
+	})
+
+	t.Run("blank name", func(t *testing.T) {
+		t.Parallel()
+
+		if err := WithRootTemplate("", "foo")(I()); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+
+	t.Run("blank template", func(t *testing.T) {
+		t.Parallel()
+
+		if err := WithRootTemplate("admin", "")(I()); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
+
+func TestWithRootTemplateFor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		i := I()
+
+		if err := WithRootTemplateFor("admin", "Admin/*", "Dashboard")(i); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := []rootTemplateRule{{name: "admin", components: []string{"Admin/*", "Dashboard"}}}
+		if !reflect.DeepEqual(i.rootTemplateRules, want) {
+			t.Fatalf("root template rules=%#v, want=%#v", i.rootTemplateRules, want)
+		}
+	})
+
+	t.Run("blank name", func(t *testing.T) {
+		t.Parallel()
+
+		if err := WithRootTemplateFor("", "Admin/*")(I()); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+
+	t.Run("no components", func(t *testing.T) {
+		t.Parallel()
+
+		if err := WithRootTemplateFor("admin")(I()); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
+
+func TestWithDevMode(t *testing.T) {
+	t.Parallel()
+
+	i := I()
+
+	option := WithDevMode()
+
+	if err := option(i); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !i.devMode {
+		t.Fatal("dev mode is not enabled")
+	}
+}
+
+func TestWithStreaming(t *testing.T) {
+	t.Parallel()
+
+	i := I()
+
+	option := WithStreaming()
+
+	if err := option(i); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !i.streaming {
+		t.Fatal("streaming is not enabled")
+	}
+}
+
+func TestWithEarlyHints(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		i := I()
+
+		config := EarlyHintsConfig{Entries: []string{"resources/js/app.ts"}}
+
+		if err := WithEarlyHints(config)(i); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if i.earlyHints == nil || !reflect.DeepEqual(i.earlyHints.Entries, config.Entries) {
+			t.Fatalf("early hints=%#v, want=%#v", i.earlyHints, config)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		if err := WithEarlyHints(EarlyHintsConfig{})(I()); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
+
+func TestWithCSP(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		i := I()
+
+		want := "script-src 'nonce-{nonce}'"
+
+		if err := WithCSP(want)(i); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if i.cspPolicy != want {
+			t.Fatalf("csp policy=%s, want=%s", i.cspPolicy, want)
+		}
+	})
+
+	t.Run("blank", func(t *testing.T) {
+		t.Parallel()
+
+		if err := WithCSP("")(I()); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
+
+func TestWithPageScriptElement(t *testing.T) {
+	t.Parallel()
+
+	i := I()
+
+	option := WithPageScriptElement()
+
+	if err := option(i); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !i.pageScriptElement {
+		t.Fatal("page script element is not enabled")
+	}
+}
+
 func TestWithJSONMarshaller(t *testing.T) {
 	t.Parallel()
 
@@ -203,12 +452,42 @@ func TestWithSSR(t *testing.T) {
 			t.Fatalf("unexpected error: %s", err)
 		}
 
-		if i.ssrHTTPClient == nil {
+		renderer, ok := i.ssr.(*HTTPSSRRenderer)
+		if !ok {
+			t.Fatalf("ssr renderer=%T, want=%T", i.ssr, renderer)
+		}
+
+		if renderer.client == nil {
 			t.Fatal("ssr http client is nil")
 		}
 
-		if i.ssrURL != wantURL {
-			t.Fatalf("ssrURL=%s, want=%s", i.containerID, wantURL)
+		if renderer.url != wantURL {
+			t.Fatalf("ssrURL=%s, want=%s", renderer.url, wantURL)
+		}
+	})
+
+	t.Run("with json marshaller", func(t *testing.T) {
+		t.Parallel()
+
+		want := "foo bar"
+
+		i, err := New(rootTemplate, WithSSR(), WithJSONMarshaller(jsonTestMarshaller{val: want}))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		renderer, ok := i.ssr.(*HTTPSSRRenderer)
+		if !ok {
+			t.Fatalf("ssr renderer=%T, want=%T", i.ssr, renderer)
+		}
+
+		got, err := renderer.jsonMarshaller.Marshal(nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if string(got) != want {
+			t.Fatalf("JSONMarshaller.Marshal()=%s, want=%s", string(got), want)
 		}
 	})
 
@@ -225,12 +504,228 @@ func TestWithSSR(t *testing.T) {
 			t.Fatalf("unexpected error: %s", err)
 		}
 
-		if i.ssrHTTPClient == nil {
+		renderer, ok := i.ssr.(*HTTPSSRRenderer)
+		if !ok {
+			t.Fatalf("ssr renderer=%T, want=%T", i.ssr, renderer)
+		}
+
+		if renderer.client == nil {
 			t.Fatal("ssr http client is nil")
 		}
 
-		if i.ssrURL != wantURL {
-			t.Fatalf("ssrURL=%s, want=%s", i.containerID, wantURL)
+		if renderer.url != wantURL {
+			t.Fatalf("ssrURL=%s, want=%s", renderer.url, wantURL)
+		}
+	})
+}
+
+func TestWithSSRConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		i := I()
+
+		want := SSRConfig{
+			Timeout:          time.Second,
+			Retries:          2,
+			FailureThreshold: 5,
+			CoolDown:         time.Minute,
+		}
+
+		option := WithSSRConfig(want)
+
+		if err := option(i); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if i.ssrConfig != want {
+			t.Fatalf("ssrConfig=%#v, want=%#v", i.ssrConfig, want)
+		}
+
+		if i.ssrBreaker == nil {
+			t.Fatal("ssr circuit breaker is nil")
+		}
+	})
+
+	t.Run("without circuit breaker", func(t *testing.T) {
+		t.Parallel()
+
+		i := I()
+
+		option := WithSSRConfig(SSRConfig{Timeout: time.Second})
+
+		if err := option(i); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if i.ssrBreaker != nil {
+			t.Fatal("ssr circuit breaker is not nil")
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		i := I()
+
+		option := WithSSRConfig(SSRConfig{FailureThreshold: 1})
+
+		if err := option(i); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
+
+func TestWithSSROnly(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		i := I()
+
+		if err := WithSSROnly("Home", "Public/*")(i); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := []string{"Home", "Public/*"}
+		if !reflect.DeepEqual(i.ssrOnly, want) {
+			t.Fatalf("ssrOnly=%#v, want=%#v", i.ssrOnly, want)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		if err := WithSSROnly()(I()); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
+
+func TestWithSSRExcept(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		i := I()
+
+		if err := WithSSRExcept("Admin/*")(i); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := []string{"Admin/*"}
+		if !reflect.DeepEqual(i.ssrExcept, want) {
+			t.Fatalf("ssrExcept=%#v, want=%#v", i.ssrExcept, want)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		if err := WithSSRExcept()(I()); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
+
+func TestWithSSRCache(t *testing.T) {
+	t.Parallel()
+
+	want, err := NewMemorySSRCache(10, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	t.Run("positive", func(t *testing.T) {
+		t.Parallel()
+
+		i := I()
+
+		option := WithSSRCache(want, "Public/*", "Home")
+
+		if err = option(i); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if i.ssrCache != want {
+			t.Fatalf("ssr cache=%v, want=%v", i.ssrCache, want)
+		}
+
+		wantOnly := []string{"Public/*", "Home"}
+		if !reflect.DeepEqual(i.ssrCacheOnly, wantOnly) {
+			t.Fatalf("ssrCacheOnly=%v, want=%v", i.ssrCacheOnly, wantOnly)
+		}
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		t.Parallel()
+
+		i := I()
+
+		option := WithSSRCache(nil)
+
+		if err := option(i); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
+
+func TestWithSSRRenderer(t *testing.T) {
+	t.Parallel()
+
+	i := I()
+
+	want := &ssrRendererMock{}
+
+	option := WithSSRRenderer(want)
+
+	if err := option(i); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if i.ssr != want {
+		t.Fatalf("ssr renderer=%v, want=%v", i.ssr, want)
+	}
+}
+
+func TestWithSSRProcess(t *testing.T) {
+	t.Parallel()
+
+	t.Run("positive", func(t *testing.T) {
+		t.Parallel()
+
+		i := I()
+
+		process, err := NewSSRProcess("ssr.mjs", WithSSRProcessURL("http://127.0.0.1:1234"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		option := WithSSRProcess(process)
+
+		if err = option(i); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		renderer, ok := i.ssr.(*HTTPSSRRenderer)
+		if !ok {
+			t.Fatalf("ssr renderer=%T, want=%T", i.ssr, renderer)
+		}
+
+		if renderer.url != process.URL() {
+			t.Fatalf("ssrURL=%s, want=%s", renderer.url, process.URL())
+		}
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := New(rootTemplate, WithSSRProcess(nil)); err == nil {
+			t.Fatal("error expected")
 		}
 	})
 }
@@ -252,3 +747,105 @@ func TestWithFlashProvider(t *testing.T) {
 		t.Fatalf("flash provider=%v, want=%s", i.flash, want)
 	}
 }
+
+func TestWithEncryptHistory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+
+		i := I()
+
+		option := WithEncryptHistory()
+
+		if err := option(i); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if !i.encryptHistory {
+			t.Fatalf("encryptHistory=%t, want=%t", i.encryptHistory, true)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		i := I(func(i *Inertia) {
+			i.encryptHistory = true
+		})
+
+		option := WithEncryptHistory(false)
+
+		if err := option(i); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if i.encryptHistory {
+			t.Fatalf("encryptHistory=%t, want=%t", i.encryptHistory, false)
+		}
+	})
+}
+
+func TestWithConcurrentPropResolution(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		i := I()
+
+		option := WithConcurrentPropResolution(4)
+
+		if err := option(i); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if i.propResolutionLimit != 4 {
+			t.Fatalf("propResolutionLimit=%d, want=%d", i.propResolutionLimit, 4)
+		}
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		t.Parallel()
+
+		i := I()
+
+		option := WithConcurrentPropResolution(0)
+
+		if err := option(i); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
+
+func TestWithPropResolutionTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		i := I()
+
+		option := WithPropResolutionTimeout(time.Second)
+
+		if err := option(i); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if i.propResolutionTimeout != time.Second {
+			t.Fatalf("propResolutionTimeout=%s, want=%s", i.propResolutionTimeout, time.Second)
+		}
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		t.Parallel()
+
+		i := I()
+
+		option := WithPropResolutionTimeout(0)
+
+		if err := option(i); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
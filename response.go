@@ -3,10 +3,15 @@ package gonertia
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
+	"maps"
 	"net/http"
+	"slices"
 	"strings"
+	"sync"
 )
 
 // TemplateData are data that will be available in the root template.
@@ -41,6 +46,48 @@ func (p AlwaysProp) Prop() any {
 	return p.Value
 }
 
+// DeferProp is a property value that will be excluded from the initial
+// page load and then loaded by the client with a follow-up partial reload.
+//
+// Deferred props with the same group are loaded in a single request.
+// If group is blank, the "default" group is used.
+//
+// https://v2.inertiajs.com/deferred-props
+type DeferProp struct {
+	Value any
+	Group string
+}
+
+func (p DeferProp) Prop() any {
+	return p.Value
+}
+
+const defaultDeferPropGroup = "default"
+
+// MergeProp is a property value that will be merged with the existing
+// client-side value (appended to arrays) instead of replacing it on partial reloads.
+//
+// https://v2.inertiajs.com/merging-props
+type MergeProp struct {
+	Value any
+}
+
+func (p MergeProp) Prop() any {
+	return p.Value
+}
+
+// DeepMergeProp is a property value that will be deeply merged with
+// the existing client-side value on partial reloads.
+//
+// https://v2.inertiajs.com/merging-props
+type DeepMergeProp struct {
+	Value any
+}
+
+func (p DeepMergeProp) Prop() any {
+	return p.Value
+}
+
 // Proper is an interface for custom type, which provides property, that will be resolved.
 type Proper interface {
 	Prop() any
@@ -51,6 +98,13 @@ type TryProper interface {
 	TryProp() (any, error)
 }
 
+// ContextProper is an interface for custom type, which provides property and error, that will be
+// resolved using the request context. Context is canceled when the client goes away
+// or the props resolution timeout is exceeded.
+type ContextProper interface {
+	ContextProp(ctx context.Context) (any, error)
+}
+
 // ValidationErrors are messages, that will be stored in the "errors" prop.
 type ValidationErrors map[string]any
 
@@ -59,7 +113,7 @@ type ValidationErrors map[string]any
 // If request was made by Inertia - sets status to 409 and url will be in "X-Inertia-Location" header.
 // Otherwise, it will do an HTTP redirect with specified status (default is 302 for GET, 303 for POST/PUT/PATCH).
 func (i *Inertia) Location(w http.ResponseWriter, r *http.Request, url string, status ...int) {
-	i.flashValidationErrorsFromContext(r.Context())
+	i.flashFromRequest(r)
 
 	if IsInertiaRequest(r) {
 		setInertiaLocationInResponse(w, url)
@@ -84,21 +138,54 @@ func backURL(r *http.Request) string {
 
 // Redirect creates plain redirect response.
 func (i *Inertia) Redirect(w http.ResponseWriter, r *http.Request, url string, status ...int) {
-	i.flashValidationErrorsFromContext(r.Context())
+	i.flashFromRequest(r)
 	redirectResponse(w, r, url, status...)
 }
 
-func (i *Inertia) flashValidationErrorsFromContext(ctx context.Context) {
+func (i *Inertia) flashFromRequest(r *http.Request) {
 	if i.flash == nil {
 		return
 	}
 
+	i.flashValidationErrorsFromContext(r.Context(), errorBagFromRequest(r))
+	i.flashPropsFromContext(r.Context())
+}
+
+func (i *Inertia) flashPropsFromContext(ctx context.Context) {
+	flashProps, ok := i.flash.(FlashPropsProvider)
+	if !ok {
+		return
+	}
+
+	props := FlashPropsFromContext(ctx)
+
+	// Clear history flag is usually set before redirect (e.g. on logout),
+	// so it's flashed to the next request along with props.
+	if ClearHistoryFromContext(ctx) {
+		props = maps.Clone(props)
+		if props == nil {
+			props = make(Props)
+		}
+		props[clearHistoryFlashKey] = true
+	}
+
+	if len(props) == 0 {
+		return
+	}
+
+	err := flashProps.FlashProps(ctx, props)
+	if err != nil {
+		i.logger.Printf("cannot flash props: %s", err)
+	}
+}
+
+func (i *Inertia) flashValidationErrorsFromContext(ctx context.Context, errorBag string) {
 	validationErrors := ValidationErrorsFromContext(ctx)
 	if len(validationErrors) == 0 {
 		return
 	}
 
-	err := i.flash.FlashErrors(ctx, validationErrors)
+	err := i.flash.FlashErrors(ctx, validationErrorsWithBag(validationErrors, errorBag))
 	if err != nil {
 		i.logger.Printf("cannot flash validation errors: %s", err)
 	}
@@ -111,6 +198,22 @@ func (i *Inertia) flashValidationErrorsFromContext(ctx context.Context) {
 //
 // If SSR is enabled, pre-renders JavaScript and return HTML (https://inertiajs.com/server-side-rendering).
 func (i *Inertia) Render(w http.ResponseWriter, r *http.Request, component string, props ...Props) (err error) {
+	if !IsInertiaRequest(r) {
+		if r, err = i.withCSPNonce(w, r); err != nil {
+			return fmt.Errorf("csp nonce: %w", err)
+		}
+
+		i.sendEarlyHints(w, component)
+	}
+
+	if i.streaming && !IsInertiaRequest(r) {
+		if err = i.doStreamingHTMLResponse(w, r, component, firstOr[Props](props, nil)); err != nil {
+			return fmt.Errorf("streaming html response: %w", err)
+		}
+
+		return nil
+	}
+
 	p, err := i.buildPage(r, component, firstOr[Props](props, nil))
 	if err != nil {
 		return fmt.Errorf("build page: %w", err)
@@ -132,39 +235,85 @@ func (i *Inertia) Render(w http.ResponseWriter, r *http.Request, component strin
 }
 
 type page struct {
-	Component string `json:"component"`
-	Props     Props  `json:"props"`
-	URL       string `json:"url"`
-	Version   string `json:"version"`
+	Component      string              `json:"component"`
+	Props          Props               `json:"props"`
+	URL            string              `json:"url"`
+	Version        string              `json:"version"`
+	EncryptHistory bool                `json:"encryptHistory"`
+	ClearHistory   bool                `json:"clearHistory"`
+	DeferredProps  map[string][]string `json:"deferredProps,omitempty"`
+	MergeProps     []string            `json:"mergeProps,omitempty"`
+	DeepMergeProps []string            `json:"deepMergeProps,omitempty"`
 }
 
 func (i *Inertia) buildPage(r *http.Request, component string, props Props) (*page, error) {
+	props = i.collectProps(r, props)
+
+	deferredProps := i.resolveDeferredProps(r, component, props)
+	mergeProps, deepMergeProps := i.resolveMergeProps(r, component, props)
+
 	props, err := i.prepareProps(r, component, props)
 	if err != nil {
 		return nil, fmt.Errorf("prepare props: %w", err)
 	}
 
 	return &page{
-		Component: component,
-		Props:     props,
-		URL:       r.RequestURI,
-		Version:   i.version,
+		Component:      component,
+		Props:          props,
+		URL:            r.RequestURI,
+		Version:        i.version,
+		EncryptHistory: i.resolveEncryptHistory(r.Context()),
+		ClearHistory:   ClearHistoryFromContext(r.Context()),
+		DeferredProps:  deferredProps,
+		MergeProps:     mergeProps,
+		DeepMergeProps: deepMergeProps,
 	}, nil
 }
 
-func (i *Inertia) prepareProps(r *http.Request, component string, props Props) (Props, error) {
+func (i *Inertia) resolveEncryptHistory(ctx context.Context) bool {
+	// Value from the context has priority over the global option.
+	if encryptHistory, ok := EncryptHistoryFromContext(ctx); ok {
+		return encryptHistory
+	}
+	return i.encryptHistory
+}
+
+// validationErrorsWithBag nests validation errors under the error bag (if any),
+// so multiple forms on the same page don't collide.
+// Errors, that are already nested under this bag (e.g. flashed ones), are returned as is.
+//
+// https://inertiajs.com/validation#error-bags
+func validationErrorsWithBag(validationErrors ValidationErrors, errorBag string) ValidationErrors {
+	if errorBag == "" || len(validationErrors) == 0 {
+		return validationErrors
+	}
+
+	if len(validationErrors) == 1 {
+		switch validationErrors[errorBag].(type) {
+		case ValidationErrors, map[string]any:
+			return validationErrors
+		}
+	}
+
+	return ValidationErrors{errorBag: validationErrors}
+}
+
+func (i *Inertia) collectProps(r *http.Request, props Props) Props {
 	result := make(Props)
 
 	{
 		// Add validation errors from context to the result.
-		result["errors"] = AlwaysProp{ValidationErrorsFromContext(r.Context())}
+		validationErrors := ValidationErrorsFromContext(r.Context())
+		result["errors"] = AlwaysProp{validationErrorsWithBag(validationErrors, errorBagFromRequest(r))}
 	}
 
 	{
 		// Add shared props to the result.
+		i.sharedMu.RLock()
 		for key, val := range i.sharedProps {
 			result[key] = val
 		}
+		i.sharedMu.RUnlock()
 
 		// Add props from context to the result.
 		for key, val := range PropsFromContext(r.Context()) {
@@ -177,38 +326,104 @@ func (i *Inertia) prepareProps(r *http.Request, component string, props Props) (
 		}
 	}
 
-	{
-		// Only (include keys) and except (exclude keys) logic.
-		only, except := i.getOnlyAndExcept(r, component)
-
-		// Filter props.
-		if len(only) > 0 {
-			for key, val := range result {
-				if _, ok := only[key]; ok {
-					continue
-				}
-				if _, ok := val.(AlwaysProp); ok {
-					continue
-				}
+	return result
+}
 
-				delete(result, key)
-			}
-		} else {
-			for key, val := range result {
-				if _, ok := val.(LazyProp); ok {
-					delete(result, key)
-				}
-			}
+// resolveDeferredProps returns keys of the deferred props grouped by their group name.
+// Deferred props are advertised only on the initial page load, otherwise
+// the client would load them again after every partial reload.
+func (i *Inertia) resolveDeferredProps(r *http.Request, component string, props Props) map[string][]string {
+	if partialComponentFromRequest(r) == component {
+		return nil
+	}
+
+	var result map[string][]string
+
+	for key, val := range props {
+		deferProp, ok := val.(DeferProp)
+		if !ok {
+			continue
+		}
+
+		group := deferProp.Group
+		if group == "" {
+			group = defaultDeferPropGroup
+		}
+
+		if result == nil {
+			result = make(map[string][]string)
+		}
+		result[group] = append(result[group], key)
+	}
+
+	// Map iteration order is random, so sort keys to keep output stable.
+	for _, keys := range result {
+		slices.Sort(keys)
+	}
+
+	return result
+}
+
+// resolveMergeProps returns keys of the props, that should be merged (or deeply merged) on the client side.
+// Props listed in the "X-Inertia-Reset" header, or filtered out by the partial reload, are skipped.
+func (i *Inertia) resolveMergeProps(r *http.Request, component string, props Props) (mergeProps, deepMergeProps []string) {
+	only, except := i.getOnlyAndExcept(r, component)
+	reset := setOf[string](resetFromRequest(r))
+
+	for key, val := range props {
+		if _, ok := reset[key]; ok {
+			continue
+		}
+		if except.matchesWhole(key) {
+			continue
+		}
+		if _, ok := only[key]; len(only) > 0 && !ok {
+			continue
 		}
-		for key := range except {
+
+		switch val.(type) {
+		case MergeProp:
+			mergeProps = append(mergeProps, key)
+		case DeepMergeProp:
+			deepMergeProps = append(deepMergeProps, key)
+		}
+	}
+
+	// Map iteration order is random, so sort keys to keep output stable.
+	slices.Sort(mergeProps)
+	slices.Sort(deepMergeProps)
+
+	return mergeProps, deepMergeProps
+}
+
+func (i *Inertia) prepareProps(r *http.Request, component string, result Props) (Props, error) {
+	// Only (include keys) and except (exclude keys) logic.
+	only, except := i.getOnlyAndExcept(r, component)
+
+	// Filter props.
+	for key, val := range result {
+		if !isPropIncluded(key, val, only, except, false) {
 			delete(result, key)
 		}
 	}
 
 	// Resolve props values.
+	ctx := r.Context()
+
+	if i.propResolutionLimit > 0 {
+		if err := i.resolvePropsConcurrently(ctx, result, only, except); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
 	for key, val := range result {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("resolve props: %w", err)
+		}
+
 		var err error
-		val, err = resolvePropVal(val)
+		val, err = i.resolvePropTree(ctx, key, val, only, except, false)
 		if err != nil {
 			return nil, fmt.Errorf("resolve prop value: %w", err)
 		}
@@ -218,7 +433,61 @@ func (i *Inertia) prepareProps(r *http.Request, component string, props Props) (
 	return result, nil
 }
 
-func (i *Inertia) getOnlyAndExcept(r *http.Request, component string) (only, except map[string]struct{}) {
+// resolvePropsConcurrently resolves props values using a bounded pool of goroutines.
+// All errors are collected and joined in the order of sorted prop keys.
+func (i *Inertia) resolvePropsConcurrently(ctx context.Context, props Props, only, except propsPath) error {
+	keys := slices.Sorted(maps.Keys(props))
+	vals := make([]any, len(keys))
+	errs := make([]error, len(keys))
+
+	sem := make(chan struct{}, i.propResolutionLimit)
+
+	var wg sync.WaitGroup
+	for idx, key := range keys {
+		val := props[key]
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return fmt.Errorf("resolve props: %w", ctx.Err())
+		}
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			// Panic in the separate goroutine can't be recovered by the http server,
+			// so it must fail the request, not the whole process.
+			defer func() {
+				if rec := recover(); rec != nil {
+					errs[idx] = fmt.Errorf("resolve prop %q: panic: %v", key, rec)
+				}
+			}()
+
+			vals[idx], errs[idx] = i.resolvePropTree(ctx, key, val, only, except, false)
+			if errs[idx] != nil {
+				errs[idx] = fmt.Errorf("resolve prop %q value: %w", key, errs[idx])
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	for idx, key := range keys {
+		props[key] = vals[idx]
+	}
+
+	return nil
+}
+
+func (i *Inertia) getOnlyAndExcept(r *http.Request, component string) (only, except propsPath) {
 	// Partial reloads only work for visits made to the same page component.
 	//
 	// https://inertiajs.com/partial-reloads
@@ -226,10 +495,134 @@ func (i *Inertia) getOnlyAndExcept(r *http.Request, component string) (only, exc
 		return nil, nil
 	}
 
-	return setOf[string](onlyFromRequest(r)), setOf[string](exceptFromRequest(r))
+	return propsPathOf(onlyFromRequest(r)), propsPathOf(exceptFromRequest(r))
+}
+
+// propsPath is a tree of dot-notated prop keys from the partial reload headers.
+// Nil subtree means that the whole prop is matched.
+type propsPath map[string]propsPath
+
+func propsPathOf(keys []string) propsPath {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	tree := make(propsPath)
+
+	for _, key := range keys {
+		node := tree
+		parts := strings.Split(key, ".")
+
+		for idx, part := range parts {
+			if idx == len(parts)-1 {
+				node[part] = nil
+				break
+			}
+
+			child, ok := node[part]
+			if ok && child == nil {
+				// The whole prop is already matched by the shorter path.
+				break
+			}
+			if !ok {
+				child = make(propsPath)
+				node[part] = child
+			}
+
+			node = child
+		}
+	}
+
+	return tree
+}
+
+// matchesWhole returns true if the whole prop with the given key is matched by the path.
+func (p propsPath) matchesWhole(key string) bool {
+	sub, ok := p[key]
+	return ok && sub == nil
+}
+
+// isPropIncluded reports whether the prop should be included into the response.
+// If whole is true, all props at this level were explicitly requested by the partial reload.
+func isPropIncluded(key string, val any, only, except propsPath, whole bool) bool {
+	if except.matchesWhole(key) {
+		return false
+	}
+
+	if whole {
+		return true
+	}
+
+	if len(only) > 0 {
+		if _, ok := only[key]; ok {
+			return true
+		}
+
+		_, ok := val.(AlwaysProp)
+		return ok
+	}
+
+	switch val.(type) {
+	case LazyProp, DeferProp:
+		return false
+	}
+
+	return true
+}
+
+// resolvePropTree resolves prop value and then nested props of this value (if any),
+// filtering them by the dot-notated partial reload paths.
+func (i *Inertia) resolvePropTree(ctx context.Context, key string, val any, only, except propsPath, whole bool) (any, error) {
+	val, err := i.resolveProp(ctx, val)
+	if err != nil {
+		return nil, err
+	}
+
+	nestedOnly, ok := only[key]
+	whole = whole || (ok && nestedOnly == nil)
+
+	switch nested := val.(type) {
+	case Props:
+		return i.resolveNestedProps(ctx, nested, nestedOnly, except[key], whole)
+	case map[string]any:
+		resolved, err := i.resolveNestedProps(ctx, nested, nestedOnly, except[key], whole)
+		return map[string]any(resolved), err
+	}
+
+	return val, nil
+}
+
+func (i *Inertia) resolveNestedProps(ctx context.Context, props Props, only, except propsPath, whole bool) (Props, error) {
+	// Nested props might be shared between requests, so we must not modify them.
+	result := make(Props, len(props))
+
+	for key, val := range props {
+		if !isPropIncluded(key, val, only, except, whole) {
+			continue
+		}
+
+		val, err := i.resolvePropTree(ctx, key, val, only, except, whole)
+		if err != nil {
+			return nil, fmt.Errorf("resolve nested prop %q: %w", key, err)
+		}
+		result[key] = val
+	}
+
+	return result, nil
+}
+
+// resolveProp resolves prop value, applying the props resolution timeout (if any) to the context.
+func (i *Inertia) resolveProp(ctx context.Context, val any) (any, error) {
+	if i.propResolutionTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, i.propResolutionTimeout)
+		defer cancel()
+	}
+
+	return resolvePropVal(ctx, val)
 }
 
-func resolvePropVal(val any) (_ any, err error) {
+func resolvePropVal(ctx context.Context, val any) (_ any, err error) {
 	switch proper := val.(type) {
 	case Proper:
 		val = proper.Prop()
@@ -238,6 +631,11 @@ func resolvePropVal(val any) (_ any, err error) {
 		if err != nil {
 			return nil, err
 		}
+	case ContextProper:
+		val, err = proper.ContextProp(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	switch typed := val.(type) {
@@ -248,6 +646,11 @@ func resolvePropVal(val any) (_ any, err error) {
 		if err != nil {
 			return nil, fmt.Errorf("closure prop resolving: %w", err)
 		}
+	case func(context.Context) (any, error):
+		val, err = typed(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("closure prop resolving: %w", err)
+		}
 	}
 
 	return val, nil
@@ -271,12 +674,9 @@ func (i *Inertia) doInertiaResponse(w http.ResponseWriter, page *page) error {
 }
 
 func (i *Inertia) doHTMLResponse(w http.ResponseWriter, r *http.Request, page *page) (err error) {
-	// If root template is already created - we'll use it to save some time.
-	if i.rootTemplate == nil {
-		i.rootTemplate, err = i.buildRootTemplate()
-		if err != nil {
-			return fmt.Errorf("build root template: %w", err)
-		}
+	rootTemplate, err := i.getRootTemplate(i.rootTemplateNameFor(r.Context(), page.Component))
+	if err != nil {
+		return fmt.Errorf("get root template: %w", err)
 	}
 
 	templateData, err := i.buildTemplateData(r, page)
@@ -286,105 +686,154 @@ func (i *Inertia) doHTMLResponse(w http.ResponseWriter, r *http.Request, page *p
 
 	setHTMLResponse(w)
 
-	if err = i.rootTemplate.Execute(w, templateData); err != nil {
+	if err = rootTemplate.Execute(w, templateData); err != nil {
 		return fmt.Errorf("execute root template: %w", err)
 	}
 
 	return nil
 }
 
-func (i *Inertia) buildRootTemplate() (*template.Template, error) {
-	tmpl := template.New("").Funcs(template.FuncMap(i.sharedTemplateFuncs))
-	return tmpl.Parse(i.rootTemplateHTML)
-}
-
 func (i *Inertia) buildTemplateData(r *http.Request, page *page) (TemplateData, error) {
-	// Defaults.
-	inertia, inertiaHead, err := i.buildInertiaHTML(page)
+	inertia, inertiaHead, err := i.buildInertiaHTML(r, page)
 	if err != nil {
 		return nil, fmt.Errorf("build inertia html: %w", err)
 	}
+
+	return i.templateDataWith(r, inertia, inertiaHead), nil
+}
+
+func (i *Inertia) templateDataWith(r *http.Request, inertia, inertiaHead template.HTML) TemplateData {
+	// Defaults.
 	templateData := TemplateData{
 		"inertia":     inertia,
 		"inertiaHead": inertiaHead,
+		"cspNonce":    CSPNonceFromContext(r.Context()),
 	}
 
 	// Add the shared template data to the result.
+	i.sharedMu.RLock()
 	for key, val := range i.sharedTemplateData {
 		templateData[key] = val
 	}
+	i.sharedMu.RUnlock()
 
 	// Add template data from context to the result.
 	for key, val := range TemplateDataFromContext(r.Context()) {
 		templateData[key] = val
 	}
 
-	return templateData, nil
+	return templateData
+}
+
+func (i *Inertia) buildInertiaHTML(r *http.Request, page *page) (inertia, inertiaHead template.HTML, _ error) {
+	inertia, inertiaHead, err := i.buildPageHTML(r, page)
+	if err != nil {
+		return "", "", err
+	}
+
+	return inertia, mergeHead(HeadFromContext(r.Context()), inertiaHead), nil
 }
 
-func (i *Inertia) buildInertiaHTML(page *page) (inertia, inertiaHead template.HTML, _ error) {
+func (i *Inertia) buildPageHTML(r *http.Request, page *page) (inertia, inertiaHead template.HTML, _ error) {
 	pageJSON, err := i.jsonMarshaller.Marshal(page)
 	if err != nil {
 		return "", "", fmt.Errorf("json marshal page into json: %w", err)
 	}
 
-	if i.isSSREnabled() {
-		inertia, inertiaHead, err = i.htmlContainerSSR(pageJSON)
+	if i.shouldRenderSSR(r.Context(), page.Component) {
+		inertia, inertiaHead, err = i.htmlContainerSSR(r.Context(), page.Component, pageJSON)
 		if err == nil {
 			return inertia, inertiaHead, nil
 		}
 
-		i.logger.Printf("ssr rendering error: %s", err)
+		i.ssrStats.fallback(err)
+
+		// Circuit breaker state changes are logged by itself.
+		if !errors.Is(err, errSSRCircuitOpen) {
+			i.logger.Printf("ssr rendering error: %s", err)
+		}
 	}
 
 	return i.htmlContainer(pageJSON)
 }
 
 func (i *Inertia) isSSREnabled() bool {
-	return i.ssrURL != "" && i.ssrHTTPClient != nil
+	return i.ssr != nil
 }
 
-// htmlContainerSSR will send request with json marshaled page payload to ssr render endpoint.
-// That endpoint will return head and body html, which will be returned and then rendered.
-func (i *Inertia) htmlContainerSSR(pageJSON []byte) (inertia, inertiaHead template.HTML, _ error) {
-	url := i.prepareSSRURL()
-
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(pageJSON))
-	if err != nil {
-		return "", "", fmt.Errorf("new http request: %w", err)
+// shouldRenderSSR reports whether the component should be rendered on the server side.
+func (i *Inertia) shouldRenderSSR(ctx context.Context, component string) bool {
+	if !i.isSSREnabled() || SSRDisabledFromContext(ctx) {
+		return false
 	}
-	setJSONRequest(req)
 
-	resp, err := i.ssrHTTPClient.Do(req)
-	if err != nil {
-		return "", "", fmt.Errorf("execute http request: %w", err)
+	if len(i.ssrOnly) > 0 && !matchComponent(component, i.ssrOnly) {
+		return false
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= http.StatusBadRequest {
-		return "", "", fmt.Errorf("invalid response status code: %d", resp.StatusCode)
-	}
+	return !matchComponent(component, i.ssrExcept)
+}
 
-	var ssr struct {
-		Head []string `json:"head"`
-		Body string   `json:"body"`
+// htmlContainerSSR will pre-render the page using SSR renderer.
+// Renderer will return head and body html, which will be returned and then rendered.
+func (i *Inertia) htmlContainerSSR(ctx context.Context, component string, pageJSON []byte) (inertia, inertiaHead template.HTML, _ error) {
+	render := i.renderSSR
+	if i.shouldCacheSSR(ctx, component) {
+		render = i.renderSSRCached
 	}
-	err = i.jsonMarshaller.Decode(resp.Body, &ssr)
+
+	head, body, err := render(ctx, pageJSON)
 	if err != nil {
-		return "", "", fmt.Errorf("json decode ssr render response: %w", err)
+		return "", "", err
 	}
 
-	inertia = template.HTML(ssr.Body)
-	inertiaHead = template.HTML(strings.Join(ssr.Head, "\n"))
+	// Nonce is different for every request, so it's added after caching.
+	head = headWithNonce(head, CSPNonceFromContext(ctx))
+
+	inertia = template.HTML(body)
+	inertiaHead = template.HTML(strings.Join(head, "\n"))
 
 	return inertia, inertiaHead, nil
 }
 
-func (i *Inertia) prepareSSRURL() string {
-	return strings.ReplaceAll(i.ssrURL, "/render", "") + "/render"
+// shouldCacheSSR reports whether server side rendered page should be cached.
+func (i *Inertia) shouldCacheSSR(ctx context.Context, component string) bool {
+	if i.ssrCache == nil || SSRCacheDisabledFromContext(ctx) {
+		return false
+	}
+
+	return len(i.ssrCacheOnly) == 0 || matchComponent(component, i.ssrCacheOnly)
+}
+
+// renderSSRCached returns the page from the SSR cache (if enabled),
+// or renders it using SSR renderer and then stores it in the cache.
+func (i *Inertia) renderSSRCached(ctx context.Context, pageJSON []byte) (head []string, body string, _ error) {
+	if i.ssrCache == nil {
+		return i.renderSSR(ctx, pageJSON)
+	}
+
+	key := i.ssrCacheKey(pageJSON)
+
+	head, body, ok := i.ssrCache.Get(ctx, key)
+	if ok {
+		return head, body, nil
+	}
+
+	head, body, err := i.renderSSR(ctx, pageJSON)
+	if err != nil {
+		return nil, "", err
+	}
+
+	i.ssrCache.Set(ctx, key, head, body)
+
+	return head, body, nil
 }
 
 func (i *Inertia) htmlContainer(pageJSON []byte) (inertia, _ template.HTML, _ error) {
+	if i.pageScriptElement {
+		return i.htmlContainerWithScript(pageJSON)
+	}
+
 	var sb strings.Builder
 
 	// It doesn't look pretty, but fast!
@@ -396,3 +845,27 @@ func (i *Inertia) htmlContainer(pageJSON []byte) (inertia, _ template.HTML, _ er
 
 	return template.HTML(sb.String()), "", nil
 }
+
+// htmlContainerWithScript puts the page into the json script element instead of the data-page attribute,
+// so it doesn't have to be html escaped as a whole. The client must be configured to read the page from it.
+func (i *Inertia) htmlContainerWithScript(pageJSON []byte) (inertia, _ template.HTML, _ error) {
+	var sb strings.Builder
+
+	sb.WriteString(`<script data-page="`)
+	sb.WriteString(i.containerID)
+	sb.WriteString(`" type="application/json">`)
+	writeScriptSafeJSON(&sb, pageJSON)
+	sb.WriteString(`</script><div id="`)
+	sb.WriteString(i.containerID)
+	sb.WriteString(`"></div>`)
+
+	return template.HTML(sb.String()), "", nil
+}
+
+// writeScriptSafeJSON writes json with escaped "<", ">" and "&" characters (and line terminators),
+// so the script element can't be closed by the page data (e.g. "</script>" in the prop value).
+func writeScriptSafeJSON(sb *strings.Builder, js []byte) {
+	var buf bytes.Buffer
+	json.HTMLEscape(&buf, js)
+	sb.Write(buf.Bytes())
+}
@@ -2,7 +2,9 @@ package gonertia
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"html/template"
 	"io"
 	"net/http"
@@ -11,6 +13,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 )
 
 //nolint:gocognit
@@ -77,8 +80,7 @@ func TestInertia_Render(t *testing.T) {
 				i := I(func(i *Inertia) {
 					i.rootTemplateHTML = rootTemplate
 					i.version = "f8v01xv4h4"
-					i.ssrURL = ts.URL
-					i.ssrHTTPClient = ts.Client()
+					i.ssr = NewHTTPSSRRenderer(ts.URL, ts.Client())
 				})
 
 				w, r := requestMock(http.MethodGet, "/home")
@@ -116,8 +118,7 @@ func TestInertia_Render(t *testing.T) {
 				i := I(func(i *Inertia) {
 					i.rootTemplateHTML = rootTemplate
 					i.version = "f8v01xv4h4"
-					i.ssrURL = ts.URL
-					i.ssrHTTPClient = ts.Client()
+					i.ssr = NewHTTPSSRRenderer(ts.URL, ts.Client())
 				})
 
 				w, r := requestMock(http.MethodGet, "/home")
@@ -137,6 +138,127 @@ func TestInertia_Render(t *testing.T) {
 			})
 		})
 
+		t.Run("ssr renderer", func(t *testing.T) {
+			t.Parallel()
+
+			renderer := &ssrRendererMock{
+				head: []string{`<title inertia>foo</title>`},
+				body: `<div id="app">foo bar</div>`,
+			}
+
+			i := I(func(i *Inertia) {
+				i.rootTemplateHTML = rootTemplate
+				i.ssr = renderer
+			})
+
+			w, r := requestMock(http.MethodGet, "/home")
+
+			err := i.Render(w, r, "Some/Component", Props{"foo": "bar"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			got := w.Body.String()
+			want := "<html>\n<head><title inertia>foo</title></head>\n<body><div id=\"app\">foo bar</div></body>\n</html>"
+			if got != want {
+				t.Fatalf("got=%s, want=%s", got, want)
+			}
+
+			assertable := AssertFromBytes(t, renderer.pageJSON)
+			assertable.AssertComponent("Some/Component")
+			assertable.AssertProps(Props{"foo": "bar", "errors": map[string]any{}})
+		})
+
+		t.Run("ssr skipped", func(t *testing.T) {
+			t.Parallel()
+
+			tests := []struct {
+				name      string
+				component string
+				configure func(i *Inertia)
+				ctx       func(ctx context.Context) context.Context
+			}{
+				{
+					name:      "except",
+					component: "Admin/Users",
+					configure: func(i *Inertia) { i.ssrExcept = []string{"Admin/*"} },
+				},
+				{
+					name:      "only",
+					component: "Admin/Users",
+					configure: func(i *Inertia) { i.ssrOnly = []string{"Public/*", "Home"} },
+				},
+				{
+					name:      "disabled in context",
+					component: "Some/Component",
+					ctx:       DisableSSR,
+				},
+			}
+
+			for _, tt := range tests {
+				tt := tt
+
+				t.Run(tt.name, func(t *testing.T) {
+					t.Parallel()
+
+					renderer := &ssrRendererMock{body: `<div id="app">foo bar</div>`}
+
+					i := I(func(i *Inertia) {
+						i.rootTemplateHTML = rootTemplate
+						i.ssr = renderer
+						if tt.configure != nil {
+							tt.configure(i)
+						}
+					})
+
+					w, r := requestMock(http.MethodGet, "/home")
+					if tt.ctx != nil {
+						r = r.WithContext(tt.ctx(r.Context()))
+					}
+
+					err := i.Render(w, r, tt.component)
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+
+					if renderer.pageJSON != nil {
+						t.Fatal("ssr renderer was called")
+					}
+
+					assertable := AssertFromString(t, w.Body.String())
+					assertable.AssertComponent(tt.component)
+				})
+			}
+		})
+
+		t.Run("page script element", func(t *testing.T) {
+			t.Parallel()
+
+			i := I(func(i *Inertia) {
+				i.rootTemplateHTML = rootTemplate
+				i.pageScriptElement = true
+			})
+
+			w, r := requestMock(http.MethodGet, "/home")
+
+			err := i.Render(w, r, "Some/Component", Props{"foo": "</script><b>&</b>"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			want := `<html>
+<head></head>
+<body><script data-page="app" type="application/json">{"component":"Some/Component","props":{"errors":{},"foo":"\u003c/script\u003e\u003cb\u003e\u0026\u003c/b\u003e"},"url":"/home","version":"","encryptHistory":false,"clearHistory":false}</script><div id="app"></div></body>
+</html>`
+			if got := w.Body.String(); got != want {
+				t.Fatalf("got=%s, want=%s", got, want)
+			}
+
+			assertable := AssertFromString(t, w.Body.String())
+			assertable.AssertComponent("Some/Component")
+			assertable.AssertProps(Props{"foo": "</script><b>&</b>", "errors": map[string]any{}})
+		})
+
 		t.Run("shared funcs", func(t *testing.T) {
 			t.Parallel()
 
@@ -248,6 +370,53 @@ func TestInertia_Render(t *testing.T) {
 			})
 		})
 
+		t.Run("history", func(t *testing.T) {
+			t.Parallel()
+
+			t.Run("encrypt globally", func(t *testing.T) {
+				t.Parallel()
+
+				i := I(func(i *Inertia) {
+					i.encryptHistory = true
+				})
+
+				w, r := requestMock(http.MethodGet, "/home")
+				asInertiaRequest(r)
+
+				err := i.Render(w, r, "Some/Component")
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				assertable := AssertFromString(t, w.Body.String())
+				assertable.AssertEncryptHistory(true)
+				assertable.AssertClearHistory(false)
+			})
+
+			t.Run("context has priority", func(t *testing.T) {
+				t.Parallel()
+
+				i := I(func(i *Inertia) {
+					i.encryptHistory = true
+				})
+
+				w, r := requestMock(http.MethodGet, "/home")
+				asInertiaRequest(r)
+
+				ctx := SetEncryptHistory(r.Context(), false)
+				ctx = ClearHistory(ctx)
+
+				err := i.Render(w, r.WithContext(ctx), "Some/Component")
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				assertable := AssertFromString(t, w.Body.String())
+				assertable.AssertEncryptHistory(false)
+				assertable.AssertClearHistory(true)
+			})
+		})
+
 		t.Run("validation errors", func(t *testing.T) {
 			t.Parallel()
 
@@ -272,6 +441,57 @@ func TestInertia_Render(t *testing.T) {
 			})
 		})
 
+		t.Run("validation errors with error bag", func(t *testing.T) {
+			t.Parallel()
+
+			w, r := requestMock(http.MethodGet, "/home")
+			asInertiaRequest(r)
+			withErrorBag(r, "createUser")
+
+			ctx := SetValidationErrors(r.Context(), ValidationErrors{"foo": "bar"})
+
+			err := I().Render(w, r.WithContext(ctx), "Some/Component")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			assertable := AssertFromString(t, w.Body.String())
+			assertable.AssertProps(Props{
+				"errors": map[string]any{
+					"createUser": map[string]any{
+						"foo": "bar",
+					},
+				},
+			})
+		})
+
+		t.Run("flashed validation errors with error bag", func(t *testing.T) {
+			t.Parallel()
+
+			w, r := requestMock(http.MethodGet, "/home")
+			asInertiaRequest(r)
+			withErrorBag(r, "createUser")
+
+			// Errors flashed by redirect are already nested under the bag.
+			ctx := SetValidationErrors(r.Context(), ValidationErrors{
+				"createUser": map[string]any{"foo": "bar"},
+			})
+
+			err := I().Render(w, r.WithContext(ctx), "Some/Component")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			assertable := AssertFromString(t, w.Body.String())
+			assertable.AssertProps(Props{
+				"errors": map[string]any{
+					"createUser": map[string]any{
+						"foo": "bar",
+					},
+				},
+			})
+		})
+
 		t.Run("props value resolving", func(t *testing.T) {
 			t.Parallel()
 
@@ -386,6 +606,412 @@ func TestInertia_Render(t *testing.T) {
 				})
 			})
 
+			t.Run("deferred props", func(t *testing.T) {
+				t.Parallel()
+
+				t.Run("initial load", func(t *testing.T) {
+					t.Parallel()
+
+					w, r := requestMock(http.MethodGet, "/home")
+					asInertiaRequest(r)
+
+					err := I().Render(w, r, "Some/Component", Props{
+						"foo":    "bar",
+						"defer1": DeferProp{Value: "prop1"},
+						"defer2": DeferProp{Value: "prop2", Group: "foobar"},
+						"defer3": DeferProp{Value: "prop3", Group: "foobar"},
+					})
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+
+					assertable := AssertFromString(t, w.Body.String())
+					assertable.AssertProps(Props{
+						"foo":    "bar",
+						"errors": map[string]any{},
+					})
+					assertable.AssertDeferredProps(map[string][]string{
+						"default": {"defer1"},
+						"foobar":  {"defer2", "defer3"},
+					})
+				})
+
+				t.Run("partial reload of group", func(t *testing.T) {
+					t.Parallel()
+
+					w, r := requestMock(http.MethodGet, "/home")
+					asInertiaRequest(r)
+					withOnly(r, []string{"defer2", "defer3"})
+					withPartialComponent(r, "Some/Component")
+
+					err := I().Render(w, r, "Some/Component", Props{
+						"foo":    "bar",
+						"defer1": DeferProp{Value: "prop1"},
+						"defer2": DeferProp{Value: "prop2", Group: "foobar"},
+						"defer3": DeferProp{Value: func() (any, error) { return "prop3", nil }, Group: "foobar"},
+					})
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+
+					assertable := AssertFromString(t, w.Body.String())
+					assertable.AssertProps(Props{
+						"defer2": "prop2",
+						"defer3": "prop3",
+						"errors": map[string]any{},
+					})
+					assertable.AssertDeferredProps(nil)
+				})
+
+				t.Run("partial reload with except", func(t *testing.T) {
+					t.Parallel()
+
+					w, r := requestMock(http.MethodGet, "/home")
+					asInertiaRequest(r)
+					withExcept(r, []string{"foo"})
+					withPartialComponent(r, "Some/Component")
+
+					err := I().Render(w, r, "Some/Component", Props{
+						"foo":    "bar",
+						"baz":    "quz",
+						"defer1": DeferProp{Value: "prop1"},
+					})
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+
+					assertable := AssertFromString(t, w.Body.String())
+					assertable.AssertProps(Props{
+						"baz":    "quz",
+						"errors": map[string]any{},
+					})
+					assertable.AssertDeferredProps(nil)
+				})
+			})
+
+			t.Run("merge props", func(t *testing.T) {
+				t.Parallel()
+
+				t.Run("success", func(t *testing.T) {
+					t.Parallel()
+
+					w, r := requestMock(http.MethodGet, "/home")
+					asInertiaRequest(r)
+
+					err := I().Render(w, r, "Some/Component", Props{
+						"foo":    "bar",
+						"merge1": MergeProp{[]int{1, 2}},
+						"merge2": MergeProp{[]int{3, 4}},
+						"deep":   DeepMergeProp{map[string]any{"baz": "quz"}},
+					})
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+
+					assertable := AssertFromString(t, w.Body.String())
+					assertable.AssertProps(Props{
+						"foo":    "bar",
+						"merge1": []any{float64(1), float64(2)},
+						"merge2": []any{float64(3), float64(4)},
+						"deep":   map[string]any{"baz": "quz"},
+						"errors": map[string]any{},
+					})
+					assertable.AssertMergeProps([]string{"merge1", "merge2"})
+					assertable.AssertDeepMergeProps([]string{"deep"})
+				})
+
+				t.Run("with reset and partial reload", func(t *testing.T) {
+					t.Parallel()
+
+					w, r := requestMock(http.MethodGet, "/home")
+					asInertiaRequest(r)
+					withOnly(r, []string{"merge1", "merge2", "deep"})
+					withReset(r, []string{"merge2"})
+					withPartialComponent(r, "Some/Component")
+
+					err := I().Render(w, r, "Some/Component", Props{
+						"merge1": MergeProp{[]int{1, 2}},
+						"merge2": MergeProp{[]int{3, 4}},
+						"merge3": MergeProp{[]int{5, 6}},
+						"deep":   DeepMergeProp{map[string]any{"baz": "quz"}},
+					})
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+
+					assertable := AssertFromString(t, w.Body.String())
+					assertable.AssertMergeProps([]string{"merge1"})
+					assertable.AssertDeepMergeProps([]string{"deep"})
+				})
+			})
+
+			t.Run("concurrent resolution", func(t *testing.T) {
+				t.Parallel()
+
+				t.Run("success", func(t *testing.T) {
+					t.Parallel()
+
+					i := I(func(i *Inertia) {
+						i.propResolutionLimit = 2
+					})
+
+					w, r := requestMock(http.MethodGet, "/home")
+					asInertiaRequest(r)
+
+					err := i.Render(w, r, "Some/Component", Props{
+						"foo":              "bar",
+						"closure":          func() any { return "prop1" },
+						"closure_with_err": func() (any, error) { return "prop2", nil },
+						"proper":           testProper{"prop3"},
+						"try_proper":       testTryProper{"prop4"},
+					})
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+
+					assertable := AssertFromString(t, w.Body.String())
+					assertable.AssertProps(Props{
+						"foo":              "bar",
+						"closure":          "prop1",
+						"closure_with_err": "prop2",
+						"proper":           "prop3",
+						"try_proper":       "prop4",
+						"errors":           map[string]any{},
+					})
+				})
+
+				t.Run("errors are joined", func(t *testing.T) {
+					t.Parallel()
+
+					i := I(func(i *Inertia) {
+						i.propResolutionLimit = 2
+					})
+
+					w, r := requestMock(http.MethodGet, "/home")
+					asInertiaRequest(r)
+
+					errFoo := errors.New("foo")
+					errBar := errors.New("bar")
+
+					err := i.Render(w, r, "Some/Component", Props{
+						"foo": func() (any, error) { return nil, errFoo },
+						"bar": func() (any, error) { return nil, errBar },
+						"baz": func() (any, error) { return "baz", nil },
+					})
+					if !errors.Is(err, errFoo) || !errors.Is(err, errBar) {
+						t.Fatalf("error=%v, want both %v and %v", err, errFoo, errBar)
+					}
+				})
+
+				t.Run("panic", func(t *testing.T) {
+					t.Parallel()
+
+					i := I(func(i *Inertia) {
+						i.propResolutionLimit = 2
+					})
+
+					w, r := requestMock(http.MethodGet, "/home")
+					asInertiaRequest(r)
+
+					err := i.Render(w, r, "Some/Component", Props{
+						"foo": func() (any, error) { panic("boom") },
+						"bar": func() (any, error) { return "bar", nil },
+					})
+					if err == nil || !strings.Contains(err.Error(), `resolve prop "foo": panic: boom`) {
+						t.Fatalf("error=%v, want panic error", err)
+					}
+				})
+			})
+
+			t.Run("context aware props", func(t *testing.T) {
+				t.Parallel()
+
+				t.Run("success", func(t *testing.T) {
+					t.Parallel()
+
+					w, r := requestMock(http.MethodGet, "/home")
+					asInertiaRequest(r)
+
+					ctx := context.WithValue(r.Context(), testContextKey{}, "value")
+
+					err := I().Render(w, r.WithContext(ctx), "Some/Component", Props{
+						"closure": func(ctx context.Context) (any, error) {
+							return ctx.Value(testContextKey{}), nil
+						},
+						"context_proper": testContextProper{},
+					})
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+
+					assertable := AssertFromString(t, w.Body.String())
+					assertable.AssertProps(Props{
+						"closure":        "value",
+						"context_proper": "value",
+						"errors":         map[string]any{},
+					})
+				})
+
+				t.Run("canceled context", func(t *testing.T) {
+					t.Parallel()
+
+					w, r := requestMock(http.MethodGet, "/home")
+					asInertiaRequest(r)
+
+					ctx, cancel := context.WithCancel(r.Context())
+					cancel()
+
+					err := I().Render(w, r.WithContext(ctx), "Some/Component", Props{
+						"foo": "bar",
+					})
+					if !errors.Is(err, context.Canceled) {
+						t.Fatalf("error=%v, want=%v", err, context.Canceled)
+					}
+				})
+
+				t.Run("timeout", func(t *testing.T) {
+					t.Parallel()
+
+					i := I(func(i *Inertia) {
+						i.propResolutionTimeout = time.Millisecond
+					})
+
+					w, r := requestMock(http.MethodGet, "/home")
+					asInertiaRequest(r)
+
+					err := i.Render(w, r, "Some/Component", Props{
+						"slow": func(ctx context.Context) (any, error) {
+							<-ctx.Done()
+							return nil, ctx.Err()
+						},
+					})
+					if !errors.Is(err, context.DeadlineExceeded) {
+						t.Fatalf("error=%v, want=%v", err, context.DeadlineExceeded)
+					}
+				})
+			})
+
+			t.Run("dot notation", func(t *testing.T) {
+				t.Parallel()
+
+				nestedProps := func() Props {
+					return Props{
+						"foo": "bar",
+						"auth": Props{
+							"user":        map[string]any{"name": "John", "email": "john@example.com"},
+							"permissions": LazyProp{func() (any, error) { return []string{"read"}, nil }},
+							"token":       AlwaysProp{"secret"},
+						},
+						"closure": func() (any, error) {
+							return map[string]any{"abc": "123", "def": "456"}, nil
+						},
+					}
+				}
+
+				t.Run("without partial reload", func(t *testing.T) {
+					t.Parallel()
+
+					w, r := requestMock(http.MethodGet, "/home")
+					asInertiaRequest(r)
+
+					err := I().Render(w, r, "Some/Component", nestedProps())
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+
+					assertable := AssertFromString(t, w.Body.String())
+					assertable.AssertProps(Props{
+						"foo": "bar",
+						"auth": map[string]any{
+							"user":  map[string]any{"name": "John", "email": "john@example.com"},
+							"token": "secret",
+						},
+						"closure": map[string]any{"abc": "123", "def": "456"},
+						"errors":  map[string]any{},
+					})
+				})
+
+				t.Run("only", func(t *testing.T) {
+					t.Parallel()
+
+					w, r := requestMock(http.MethodGet, "/home")
+					asInertiaRequest(r)
+					withOnly(r, []string{"auth.user.name", "auth.permissions", "closure.def"})
+					withPartialComponent(r, "Some/Component")
+
+					err := I().Render(w, r, "Some/Component", nestedProps())
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+
+					assertable := AssertFromString(t, w.Body.String())
+					assertable.AssertProps(Props{
+						"auth": map[string]any{
+							"user":        map[string]any{"name": "John"},
+							"permissions": []any{"read"},
+							"token":       "secret",
+						},
+						"closure": map[string]any{"def": "456"},
+						"errors":  map[string]any{},
+					})
+				})
+
+				t.Run("only whole nested prop", func(t *testing.T) {
+					t.Parallel()
+
+					w, r := requestMock(http.MethodGet, "/home")
+					asInertiaRequest(r)
+					withOnly(r, []string{"auth", "auth.user.name"})
+					withPartialComponent(r, "Some/Component")
+
+					err := I().Render(w, r, "Some/Component", nestedProps())
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+
+					assertable := AssertFromString(t, w.Body.String())
+					assertable.AssertProps(Props{
+						"auth": map[string]any{
+							"user":        map[string]any{"name": "John", "email": "john@example.com"},
+							"permissions": []any{"read"},
+							"token":       "secret",
+						},
+						"errors": map[string]any{},
+					})
+				})
+
+				t.Run("except", func(t *testing.T) {
+					t.Parallel()
+
+					w, r := requestMock(http.MethodGet, "/home")
+					asInertiaRequest(r)
+					withExcept(r, []string{"auth.user.email", "auth.token", "closure.abc"})
+					withPartialComponent(r, "Some/Component")
+
+					props := nestedProps()
+
+					err := I().Render(w, r, "Some/Component", props)
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+
+					assertable := AssertFromString(t, w.Body.String())
+					assertable.AssertProps(Props{
+						"foo": "bar",
+						"auth": map[string]any{
+							"user": map[string]any{"name": "John"},
+						},
+						"closure": map[string]any{"def": "456"},
+						"errors":  map[string]any{},
+					})
+
+					// Passed props must stay untouched.
+					if _, ok := props["auth"].(Props)["user"].(map[string]any)["email"]; !ok {
+						t.Fatal("passed nested props were modified")
+					}
+				})
+			})
+
 			t.Run("proper interfaces", func(t *testing.T) {
 				t.Parallel()
 
@@ -413,6 +1039,67 @@ func TestInertia_Render(t *testing.T) {
 	})
 }
 
+func Test_writeScriptSafeJSON(t *testing.T) {
+	t.Parallel()
+
+	var sb strings.Builder
+	writeScriptSafeJSON(&sb, []byte("{\"foo\":\"</script>&\u2028\"}"))
+
+	want := `{"foo":"\u003c/script\u003e\u0026\u2028"}`
+	if got := sb.String(); got != want {
+		t.Fatalf("got=%s, want=%s", got, want)
+	}
+}
+
+func Test_propsPathOf(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		keys []string
+		want propsPath
+	}{
+		{
+			name: "nil",
+			keys: nil,
+			want: nil,
+		},
+		{
+			name: "top level",
+			keys: []string{"foo", "bar"},
+			want: propsPath{"foo": nil, "bar": nil},
+		},
+		{
+			name: "nested",
+			keys: []string{"foo.bar", "foo.baz.quz", "abc"},
+			want: propsPath{
+				"foo": propsPath{
+					"bar": nil,
+					"baz": propsPath{"quz": nil},
+				},
+				"abc": nil,
+			},
+		},
+		{
+			name: "whole prop wins",
+			keys: []string{"foo.bar", "foo", "foo.baz"},
+			want: propsPath{"foo": nil},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := propsPathOf(tt.keys)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("propsPathOf()=%#v, want=%#v", got, tt.want)
+			}
+		})
+	}
+}
+
 type testProper struct {
 	Value any
 }
@@ -421,6 +1108,14 @@ func (p testProper) Prop() any {
 	return p.Value
 }
 
+type testContextKey struct{}
+
+type testContextProper struct{}
+
+func (p testContextProper) ContextProp(ctx context.Context) (any, error) {
+	return ctx.Value(testContextKey{}), nil
+}
+
 type testTryProper struct {
 	Value any
 }
@@ -604,6 +1299,75 @@ func TestInertia_Redirect(t *testing.T) {
 			t.Fatalf("got validation errors=%#v, want=%#v", flashProvider.errors, errors)
 		}
 	})
+
+	t.Run("flash validation errors with error bag", func(t *testing.T) {
+		t.Parallel()
+
+		w, r := requestMock(http.MethodPost, "/")
+		asInertiaRequest(r)
+		withErrorBag(r, "createUser")
+
+		flashProvider := &flashProviderMock{}
+
+		i := I(func(i *Inertia) {
+			i.flash = flashProvider
+		})
+
+		withValidationErrors(r, ValidationErrors{"foo": "bar"})
+		i.Redirect(w, r, "https://example.com/foo")
+
+		want := ValidationErrors{"createUser": ValidationErrors{"foo": "bar"}}
+		if !reflect.DeepEqual(flashProvider.errors, want) {
+			t.Fatalf("got validation errors=%#v, want=%#v", flashProvider.errors, want)
+		}
+	})
+
+	t.Run("flash props", func(t *testing.T) {
+		t.Parallel()
+
+		w, r := requestMock(http.MethodGet, "/")
+
+		flashProvider := &flashProviderMock{}
+
+		i := I(func(i *Inertia) {
+			i.flash = flashProvider
+		})
+
+		ctx := FlashProp(r.Context(), "message", "Saved!")
+		i.Redirect(w, r.WithContext(ctx), "https://example.com/foo")
+
+		want := Props{"message": "Saved!"}
+		if !reflect.DeepEqual(flashProvider.props, want) {
+			t.Fatalf("got flash props=%#v, want=%#v", flashProvider.props, want)
+		}
+	})
+
+	t.Run("clear history", func(t *testing.T) {
+		t.Parallel()
+
+		flashProvider := &flashProviderMock{}
+
+		i := I(func(i *Inertia) {
+			i.flash = flashProvider
+		})
+
+		w, r := requestMock(http.MethodPost, "/logout")
+		i.Redirect(w, r.WithContext(ClearHistory(r.Context())), "/login")
+
+		// The next request after redirect.
+		w, r = requestMock(http.MethodGet, "/login")
+		asInertiaRequest(r)
+
+		i.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := i.Render(w, r, "Login"); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})).ServeHTTP(w, r)
+
+		assertable := AssertFromString(t, w.Body.String())
+		assertable.AssertClearHistory(true)
+		assertable.AssertProps(Props{"errors": map[string]any{}})
+	})
 }
 
 func TestInertia_Back(t *testing.T) {
@@ -0,0 +1,208 @@
+package gonertia
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"os"
+	"path"
+	"text/template/parse"
+)
+
+// defaultRootTemplate is a name of the default root template in the root templates cache.
+const defaultRootTemplate = ""
+
+// rootTemplateRule selects the root template by the component name.
+type rootTemplateRule struct {
+	name       string
+	components []string
+}
+
+// rootTemplateNameFor returns the name of the root template for the component:
+// the name from the context, then the first matched component rule, then the default root template.
+func (i *Inertia) rootTemplateNameFor(ctx context.Context, component string) string {
+	if name := RootTemplateFromContext(ctx); name != "" {
+		return name
+	}
+
+	for _, rule := range i.rootTemplateRules {
+		if matchComponent(component, rule.components) {
+			return rule.name
+		}
+	}
+
+	return defaultRootTemplate
+}
+
+// getRootTemplate returns the parsed root template by name.
+func (i *Inertia) getRootTemplate(name string) (*template.Template, error) {
+	i.rootTemplateMu.Lock()
+	defer i.rootTemplateMu.Unlock()
+
+	if i.devMode {
+		if err := i.reloadRootTemplate(); err != nil {
+			return nil, fmt.Errorf("reload root template: %w", err)
+		}
+	}
+
+	// If root template is already created - we'll use it to save some time.
+	if tmpl, ok := i.rootTemplates[name]; ok {
+		return tmpl, nil
+	}
+
+	tmpl, err := i.buildRootTemplate(name)
+	if err != nil {
+		return nil, fmt.Errorf("build root template: %w", err)
+	}
+
+	if i.rootTemplates == nil {
+		i.rootTemplates = make(map[string]*template.Template)
+	}
+	i.rootTemplates[name] = tmpl
+
+	return tmpl, nil
+}
+
+// reloadRootTemplate re-reads the root template file if it has been modified since the last read.
+func (i *Inertia) reloadRootTemplate() error {
+	// File system may not support modification time (e.g. embed.FS),
+	// so the templates are just parsed again.
+	if i.rootTemplateFS != nil {
+		clear(i.rootTemplates)
+		return nil
+	}
+
+	if i.rootTemplatePath == "" {
+		return nil
+	}
+
+	info, err := os.Stat(i.rootTemplatePath)
+	if err != nil {
+		return fmt.Errorf("stat file %q: %w", i.rootTemplatePath, err)
+	}
+
+	if info.ModTime().Equal(i.rootTemplateModTime) {
+		return nil
+	}
+
+	bs, err := os.ReadFile(i.rootTemplatePath)
+	if err != nil {
+		return fmt.Errorf("read file %q: %w", i.rootTemplatePath, err)
+	}
+
+	i.rootTemplateHTML = string(bs)
+	i.rootTemplateModTime = info.ModTime()
+	delete(i.rootTemplates, defaultRootTemplate)
+
+	return nil
+}
+
+func (i *Inertia) buildRootTemplate(name string) (*template.Template, error) {
+	i.sharedMu.RLock()
+	tmpl := template.New("").Funcs(template.FuncMap(i.sharedTemplateFuncs))
+	i.sharedMu.RUnlock()
+
+	// Named root templates are parsed separately, so they can define the same partials.
+	if html, ok := i.namedRootTemplatesHTML[name]; ok {
+		return tmpl.Parse(html)
+	}
+
+	if i.rootTemplateFS == nil {
+		if name != defaultRootTemplate {
+			return nil, fmt.Errorf("root template %q not found", name)
+		}
+
+		return tmpl.Parse(i.rootTemplateHTML)
+	}
+
+	if name == defaultRootTemplate {
+		name = i.rootTemplateName
+	}
+
+	tmpl, err := tmpl.ParseFS(i.rootTemplateFS, i.rootTemplatePatterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	root := tmpl.Lookup(name)
+	if root == nil {
+		return nil, fmt.Errorf("root template %q not found", name)
+	}
+
+	return root, nil
+}
+
+// validateRootTemplates checks the syntax of the root templates and that the root template names
+// are found, so the invalid template is reported on initialization rather than on the first render.
+//
+// Template funcs are not checked, because they can be shared after initialization.
+func (i *Inertia) validateRootTemplates() error {
+	// Names of the templates from fs, as they are named by template.ParseFS.
+	fsNames := make(map[string]struct{})
+
+	if i.rootTemplateFS == nil {
+		if _, err := parseTemplateSyntax("root", i.rootTemplateHTML); err != nil {
+			return err
+		}
+	} else {
+		for _, pattern := range i.rootTemplatePatterns {
+			matches, err := fs.Glob(i.rootTemplateFS, pattern)
+			if err != nil {
+				return fmt.Errorf("match root template pattern %q: %w", pattern, err)
+			}
+
+			for _, name := range matches {
+				bs, err := fs.ReadFile(i.rootTemplateFS, name)
+				if err != nil {
+					return fmt.Errorf("read root template %q: %w", name, err)
+				}
+
+				trees, err := parseTemplateSyntax(name, string(bs))
+				if err != nil {
+					return err
+				}
+
+				fsNames[path.Base(name)] = struct{}{}
+				for treeName := range trees {
+					if treeName != name {
+						fsNames[treeName] = struct{}{}
+					}
+				}
+			}
+		}
+
+		if _, ok := fsNames[i.rootTemplateName]; !ok {
+			return fmt.Errorf("root template %q not found", i.rootTemplateName)
+		}
+	}
+
+	for name, html := range i.namedRootTemplatesHTML {
+		if _, err := parseTemplateSyntax(name, html); err != nil {
+			return err
+		}
+	}
+
+	for _, rule := range i.rootTemplateRules {
+		_, named := i.namedRootTemplatesHTML[rule.name]
+		_, fromFS := fsNames[rule.name]
+		if !named && !fromFS {
+			return fmt.Errorf("root template %q not found", rule.name)
+		}
+	}
+
+	return nil
+}
+
+// parseTemplateSyntax parses the template text without checking funcs and returns the parsed templates by name.
+func parseTemplateSyntax(name, text string) (map[string]*parse.Tree, error) {
+	tree := parse.New(name)
+	tree.Mode = parse.SkipFuncCheck
+
+	trees := make(map[string]*parse.Tree)
+	if _, err := tree.Parse(text, "", "", trees); err != nil {
+		return nil, fmt.Errorf("parse root template: %w", err)
+	}
+
+	return trees, nil
+}
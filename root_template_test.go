@@ -0,0 +1,231 @@
+package gonertia
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestInertia_getRootTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		devMode bool
+		want    string
+	}{
+		{"dev mode", true, "bar"},
+		{"production mode", false, "foo"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := tmpFile(t, "foo")
+
+			i, err := NewFromFile(f.Name(), WithDevMode(tt.devMode))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := renderRootTemplate(t, i); got != "foo" {
+				t.Fatalf("got=%s, want=%s", got, "foo")
+			}
+
+			if err = os.WriteFile(f.Name(), []byte("bar"), 0o600); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			// Make sure modification time is changed on file systems with low time resolution.
+			modTime := time.Now().Add(time.Minute)
+			if err = os.Chtimes(f.Name(), modTime, modTime); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := renderRootTemplate(t, i); got != tt.want {
+				t.Fatalf("got=%s, want=%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func renderRootTemplate(t *testing.T, i *Inertia) string {
+	t.Helper()
+
+	w, r := requestMock(http.MethodGet, "/")
+
+	if err := i.Render(w, r, "Some/Component"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return w.Body.String()
+}
+
+func TestInertia_rootTemplateNameFor(t *testing.T) {
+	t.Parallel()
+
+	i := I(func(i *Inertia) {
+		i.rootTemplateRules = []rootTemplateRule{
+			{name: "admin", components: []string{"Admin/*"}},
+			{name: "auth", components: []string{"Auth/Login", "Auth/Register"}},
+		}
+	})
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		component string
+		want      string
+	}{
+		{"default", context.Background(), "Home", defaultRootTemplate},
+		{"prefix rule", context.Background(), "Admin/Users/Index", "admin"},
+		{"exact rule", context.Background(), "Auth/Login", "auth"},
+		{"context", SetRootTemplate(context.Background(), "print"), "Admin/Users/Index", "print"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := i.rootTemplateNameFor(tt.ctx, tt.component); got != tt.want {
+				t.Fatalf("root template=%q, want=%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInertia_Render_rootTemplates(t *testing.T) {
+	t.Parallel()
+
+	t.Run("named root templates", func(t *testing.T) {
+		t.Parallel()
+
+		i, err := New(
+			`{{ define "title" }}public{{ end }}{{ template "title" }}`,
+			WithRootTemplate("admin", `{{ define "title" }}admin{{ end }}{{ template "title" }}`),
+			WithRootTemplateFor("admin", "Admin/*"),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		tests := map[string]string{
+			"Home":        "public",
+			"Admin/Users": "admin",
+			"Home/Admin":  "public",
+		}
+
+		for component, want := range tests {
+			if got := renderComponent(t, i, context.Background(), component); got != want {
+				t.Fatalf("component %q: got=%s, want=%s", component, got, want)
+			}
+		}
+
+		got := renderComponent(t, i, SetRootTemplate(context.Background(), "admin"), "Home")
+		if got != "admin" {
+			t.Fatalf("got=%s, want=%s", got, "admin")
+		}
+	})
+
+	t.Run("templates from fs", func(t *testing.T) {
+		t.Parallel()
+
+		fsys := fstest.MapFS{
+			"root.html":  {Data: []byte(`public`)},
+			"admin.html": {Data: []byte(`admin`)},
+		}
+
+		i, err := NewFromFS(
+			fsys,
+			[]string{"*.html"},
+			WithRootTemplateName("root.html"),
+			WithRootTemplateFor("admin.html", "Admin/*"),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got := renderComponent(t, i, context.Background(), "Home"); got != "public" {
+			t.Fatalf("got=%s, want=%s", got, "public")
+		}
+
+		if got := renderComponent(t, i, context.Background(), "Admin/Users"); got != "admin" {
+			t.Fatalf("got=%s, want=%s", got, "admin")
+		}
+	})
+
+	t.Run("template defined in fs", func(t *testing.T) {
+		t.Parallel()
+
+		fsys := fstest.MapFS{
+			"root.html":    {Data: []byte(`public`)},
+			"layouts.html": {Data: []byte(`{{ define "admin" }}admin{{ end }}`)},
+		}
+
+		i, err := NewFromFS(
+			fsys,
+			[]string{"root.html", "layouts.html"},
+			WithRootTemplateFor("admin", "Admin/*"),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got := renderComponent(t, i, context.Background(), "Admin/Users"); got != "admin" {
+			t.Fatalf("got=%s, want=%s", got, "admin")
+		}
+	})
+
+	t.Run("unknown root template in rule", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(rootTemplate, WithRootTemplate("admin", rootTemplate), WithRootTemplateFor("admni", "Admin/*"))
+		if err == nil {
+			t.Fatal("error expected")
+		}
+
+		_, err = NewFromFS(
+			fstest.MapFS{"root.html": {Data: []byte(`public`)}},
+			[]string{"*.html"},
+			WithRootTemplateFor("admin.html", "Admin/*"),
+		)
+		if err == nil {
+			t.Fatal("error expected")
+		}
+	})
+
+	t.Run("unknown root template", func(t *testing.T) {
+		t.Parallel()
+
+		i := I(func(i *Inertia) {
+			i.rootTemplateHTML = rootTemplate
+		})
+
+		w, r := requestMock(http.MethodGet, "/")
+		r = r.WithContext(SetRootTemplate(r.Context(), "unknown"))
+
+		if err := i.Render(w, r, "Home"); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
+
+func renderComponent(t *testing.T, i *Inertia, ctx context.Context, component string) string {
+	t.Helper()
+
+	w, r := requestMock(http.MethodGet, "/")
+
+	if err := i.Render(w, r.WithContext(ctx), component); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return w.Body.String()
+}
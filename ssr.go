@@ -0,0 +1,257 @@
+package gonertia
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SSRRenderer defines an interface for server side renderer of the page.
+//
+// It receives json marshaled page and returns head tags and body html.
+type SSRRenderer interface {
+	Render(ctx context.Context, pageJSON []byte) (head []string, body string, err error)
+}
+
+// HTTPSSRRenderer is SSRRenderer, that sends page to the Inertia SSR server over HTTP.
+//
+// https://inertiajs.com/server-side-rendering
+type HTTPSSRRenderer struct {
+	url            string
+	client         *http.Client
+	jsonMarshaller JSONMarshaller
+}
+
+var _ SSRRenderer = (*HTTPSSRRenderer)(nil)
+
+// defaultSSRTimeout is a timeout of the single render attempt and the health check,
+// so a hung SSR server won't block requests forever.
+const defaultSSRTimeout = 5 * time.Second
+
+// NewHTTPSSRRenderer initializes and returns HTTPSSRRenderer.
+// If client is nil, http.DefaultClient will be used.
+func NewHTTPSSRRenderer(url string, client *http.Client) *HTTPSSRRenderer {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &HTTPSSRRenderer{
+		url:            url,
+		client:         client,
+		jsonMarshaller: jsonDefaultMarshaller{},
+	}
+}
+
+// newHTTPSSRRenderer returns HTTPSSRRenderer, that uses Inertia's JSON marshaller.
+func (i *Inertia) newHTTPSSRRenderer(url string) *HTTPSSRRenderer {
+	renderer := NewHTTPSSRRenderer(url, &http.Client{})
+	renderer.jsonMarshaller = inertiaJSONMarshaller{i}
+	return renderer
+}
+
+// Render sends request with json marshaled page payload to the SSR render endpoint.
+// That endpoint will return head and body html.
+func (s *HTTPSSRRenderer) Render(ctx context.Context, pageJSON []byte) (head []string, body string, _ error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.renderURL(), bytes.NewReader(pageJSON))
+	if err != nil {
+		return nil, "", fmt.Errorf("new http request: %w", err)
+	}
+	setJSONRequest(req)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("execute http request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, "", fmt.Errorf("invalid response status code: %d", resp.StatusCode)
+	}
+
+	var ssr struct {
+		Head []string `json:"head"`
+		Body string   `json:"body"`
+	}
+	err = s.jsonMarshaller.Decode(resp.Body, &ssr)
+	if err != nil {
+		return nil, "", fmt.Errorf("json decode ssr render response: %w", err)
+	}
+
+	return ssr.Head, ssr.Body, nil
+}
+
+func (s *HTTPSSRRenderer) renderURL() string {
+	return strings.ReplaceAll(s.url, "/render", "") + "/render"
+}
+
+// SSRConfig is a configuration of the server side rendering.
+type SSRConfig struct {
+	// Timeout is a timeout of the single render attempt.
+	// Zero means the default timeout (5 seconds).
+	Timeout time.Duration
+
+	// Retries is a number of additional render attempts after the failed one.
+	Retries int
+
+	// FailureThreshold is a number of consecutive failed renders, after which SSR
+	// is skipped (page is rendered on the client side) for the CoolDown duration.
+	// Zero disables the circuit breaker.
+	FailureThreshold int
+
+	// CoolDown is a duration, during which SSR is skipped after too many failures.
+	// After that, a single render attempt is made to check if SSR works again.
+	CoolDown time.Duration
+}
+
+var errSSRCircuitOpen = errors.New("ssr circuit breaker is open")
+
+// renderSSR renders the page using SSR renderer, respecting the timeout, retries and circuit breaker.
+func (i *Inertia) renderSSR(ctx context.Context, pageJSON []byte) (head []string, body string, err error) {
+	if i.ssrBreaker != nil {
+		allowed, halfOpened := i.ssrBreaker.allow()
+		if !allowed {
+			return nil, "", errSSRCircuitOpen
+		}
+		if halfOpened {
+			i.logger.Println("ssr circuit breaker is half-open, trying to render")
+		}
+	}
+
+	attempts := i.ssrConfig.Retries + 1
+	for attempt := 1; attempt <= attempts; attempt++ {
+		head, body, err = i.renderSSRAttempt(ctx, pageJSON)
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if i.ssrBreaker != nil {
+		if err != nil && i.ssrBreaker.failure() {
+			i.logger.Printf("ssr circuit breaker is open for %s after error: %s", i.ssrConfig.CoolDown, err)
+		}
+		if err == nil && i.ssrBreaker.success() {
+			i.logger.Println("ssr circuit breaker is closed")
+		}
+	}
+
+	if err != nil && attempts > 1 {
+		return nil, "", fmt.Errorf("%d attempts failed: %w", attempts, err)
+	}
+
+	return head, body, err
+}
+
+func (i *Inertia) renderSSRAttempt(ctx context.Context, pageJSON []byte) ([]string, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, i.ssrTimeout())
+	defer cancel()
+
+	return i.ssr.Render(ctx, pageJSON)
+}
+
+// ssrTimeout returns the timeout of the single request to the SSR server.
+func (i *Inertia) ssrTimeout() time.Duration {
+	if i.ssrConfig.Timeout > 0 {
+		return i.ssrConfig.Timeout
+	}
+	return defaultSSRTimeout
+}
+
+type ssrCircuitState int
+
+const (
+	ssrCircuitClosed ssrCircuitState = iota
+	ssrCircuitOpen
+	ssrCircuitHalfOpen
+)
+
+// ssrCircuitBreaker skips SSR for the cool down duration after too many consecutive failures,
+// so a crashed SSR server won't slow down every request.
+type ssrCircuitBreaker struct {
+	threshold int
+	coolDown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    ssrCircuitState
+	failures int
+	openedAt time.Time
+}
+
+func newSSRCircuitBreaker(threshold int, coolDown time.Duration) *ssrCircuitBreaker {
+	return &ssrCircuitBreaker{
+		threshold: threshold,
+		coolDown:  coolDown,
+		now:       time.Now,
+	}
+}
+
+// allow reports whether the render is allowed, and whether the breaker has just become half-open.
+func (b *ssrCircuitBreaker) allow() (allowed, halfOpened bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case ssrCircuitClosed:
+		return true, false
+	case ssrCircuitOpen:
+		if b.now().Sub(b.openedAt) < b.coolDown {
+			return false, false
+		}
+
+		// Let one render go through to check if SSR works again.
+		b.state = ssrCircuitHalfOpen
+		return true, true
+	case ssrCircuitHalfOpen:
+		// Trial render is in progress.
+		return false, false
+	}
+
+	return false, false
+}
+
+// isOpen reports whether renders are skipped.
+func (b *ssrCircuitBreaker) isOpen() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state != ssrCircuitClosed
+}
+
+// success records the successful render and reports whether the breaker has just been closed.
+func (b *ssrCircuitBreaker) success() (closed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+
+	if b.state != ssrCircuitHalfOpen {
+		return false
+	}
+
+	b.state = ssrCircuitClosed
+	return true
+}
+
+// failure records the failed render and reports whether the breaker has just been opened.
+func (b *ssrCircuitBreaker) failure() (opened bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+
+	if b.state == ssrCircuitOpen {
+		return false
+	}
+	if b.state == ssrCircuitClosed && b.failures < b.threshold {
+		return false
+	}
+
+	b.state = ssrCircuitOpen
+	b.openedAt = b.now()
+	return true
+}
@@ -0,0 +1,135 @@
+package gonertia
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SSRCache defines an interface for cache of the server side rendered pages.
+//
+// Key is a hash of the json marshaled page and asset version,
+// so the cache is most effective for pages, that are identical for all visitors.
+type SSRCache interface {
+	Get(ctx context.Context, key string) (head []string, body string, ok bool)
+	Set(ctx context.Context, key string, head []string, body string)
+}
+
+// MemorySSRCache is an in-memory SSRCache with the LRU eviction policy.
+type MemorySSRCache struct {
+	maxSize int
+	ttl     time.Duration
+	now     func() time.Time
+
+	mu      sync.Mutex
+	items   map[string]*list.Element
+	evictor *list.List
+}
+
+var _ SSRCache = (*MemorySSRCache)(nil)
+
+type memorySSRCacheItem struct {
+	key       string
+	head      []string
+	body      string
+	expiresAt time.Time
+}
+
+// NewMemorySSRCache initializes and returns MemorySSRCache, that holds not more than maxSize pages.
+// Pages expire after ttl, zero ttl means pages never expire (but still can be evicted).
+func NewMemorySSRCache(maxSize int, ttl time.Duration) (*MemorySSRCache, error) {
+	if maxSize < 1 {
+		return nil, fmt.Errorf("invalid ssr cache max size: %d", maxSize)
+	}
+	if ttl < 0 {
+		return nil, fmt.Errorf("invalid ssr cache ttl: %s", ttl)
+	}
+
+	return &MemorySSRCache{
+		maxSize: maxSize,
+		ttl:     ttl,
+		now:     time.Now,
+		items:   make(map[string]*list.Element, maxSize),
+		evictor: list.New(),
+	}, nil
+}
+
+// Get returns the cached page.
+func (c *MemorySSRCache) Get(_ context.Context, key string) (head []string, body string, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, "", false
+	}
+
+	item := elem.Value.(*memorySSRCacheItem)
+	if c.isExpired(item) {
+		c.remove(elem)
+		return nil, "", false
+	}
+
+	c.evictor.MoveToFront(elem)
+
+	return item.head, item.body, true
+}
+
+// Set stores the page in the cache, evicting the least recently used page if the cache is full.
+func (c *MemorySSRCache) Set(_ context.Context, key string, head []string, body string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if c.ttl > 0 {
+		expiresAt = c.now().Add(c.ttl)
+	}
+
+	if elem, ok := c.items[key]; ok {
+		item := elem.Value.(*memorySSRCacheItem)
+		item.head, item.body, item.expiresAt = head, body, expiresAt
+		c.evictor.MoveToFront(elem)
+		return
+	}
+
+	c.items[key] = c.evictor.PushFront(&memorySSRCacheItem{
+		key:       key,
+		head:      head,
+		body:      body,
+		expiresAt: expiresAt,
+	})
+
+	for c.evictor.Len() > c.maxSize {
+		c.remove(c.evictor.Back())
+	}
+}
+
+// Len returns the number of cached pages.
+func (c *MemorySSRCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.evictor.Len()
+}
+
+func (c *MemorySSRCache) isExpired(item *memorySSRCacheItem) bool {
+	return !item.expiresAt.IsZero() && !c.now().Before(item.expiresAt)
+}
+
+func (c *MemorySSRCache) remove(elem *list.Element) {
+	c.evictor.Remove(elem)
+	delete(c.items, elem.Value.(*memorySSRCacheItem).key)
+}
+
+// ssrCacheKey returns the cache key of the page.
+func (i *Inertia) ssrCacheKey(pageJSON []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(i.version))
+	hash.Write([]byte{0})
+	hash.Write(pageJSON)
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,272 @@
+package gonertia
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewMemorySSRCache(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		maxSize int
+		ttl     time.Duration
+		wantErr bool
+	}{
+		{"success", 10, time.Minute, false},
+		{"without ttl", 10, 0, false},
+		{"invalid max size", 0, time.Minute, true},
+		{"invalid ttl", 10, -time.Minute, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewMemorySSRCache(tt.maxSize, tt.ttl)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error=%v, wantErr=%t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMemorySSRCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("get and set", func(t *testing.T) {
+		t.Parallel()
+
+		c := memorySSRCache(t, 10, 0)
+
+		if _, _, ok := c.Get(ctx, "foo"); ok {
+			t.Fatal("unexpected cache hit")
+		}
+
+		c.Set(ctx, "foo", []string{"<title>foo</title>"}, "bar")
+
+		head, body, ok := c.Get(ctx, "foo")
+		if !ok {
+			t.Fatal("unexpected cache miss")
+		}
+
+		if !reflect.DeepEqual(head, []string{"<title>foo</title>"}) || body != "bar" {
+			t.Fatalf("head=%#v, body=%s", head, body)
+		}
+
+		c.Set(ctx, "foo", nil, "baz")
+
+		if _, body, _ = c.Get(ctx, "foo"); body != "baz" {
+			t.Fatalf("body=%s, want=%s", body, "baz")
+		}
+
+		if c.Len() != 1 {
+			t.Fatalf("len=%d, want=%d", c.Len(), 1)
+		}
+	})
+
+	t.Run("evicts least recently used", func(t *testing.T) {
+		t.Parallel()
+
+		c := memorySSRCache(t, 2, 0)
+
+		c.Set(ctx, "foo", nil, "foo")
+		c.Set(ctx, "bar", nil, "bar")
+		c.Get(ctx, "foo")
+		c.Set(ctx, "baz", nil, "baz")
+
+		if _, _, ok := c.Get(ctx, "bar"); ok {
+			t.Fatal("least recently used page is not evicted")
+		}
+
+		for _, key := range []string{"foo", "baz"} {
+			if _, _, ok := c.Get(ctx, key); !ok {
+				t.Fatalf("page %q is evicted", key)
+			}
+		}
+	})
+
+	t.Run("expires", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+
+		c := memorySSRCache(t, 10, time.Minute)
+		c.now = func() time.Time { return now }
+
+		c.Set(ctx, "foo", nil, "foo")
+
+		now = now.Add(59 * time.Second)
+		if _, _, ok := c.Get(ctx, "foo"); !ok {
+			t.Fatal("page is expired too early")
+		}
+
+		now = now.Add(time.Second)
+		if _, _, ok := c.Get(ctx, "foo"); ok {
+			t.Fatal("page is not expired")
+		}
+
+		if c.Len() != 0 {
+			t.Fatalf("len=%d, want=%d", c.Len(), 0)
+		}
+	})
+}
+
+func TestInertia_renderSSRCached(t *testing.T) {
+	t.Parallel()
+
+	t.Run("renders once", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+
+		i := I(func(i *Inertia) {
+			i.rootTemplateHTML = rootTemplate
+			i.ssrCache = memorySSRCache(t, 10, 0)
+			i.ssr = ssrRendererFunc(func(context.Context, []byte) ([]string, string, error) {
+				calls++
+				return []string{"<title>foo</title>"}, "bar", nil
+			})
+		})
+
+		for range 2 {
+			w, r := requestMock(http.MethodGet, "/")
+
+			if err := i.Render(w, r, "Some/Component", Props{"foo": "bar"}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			want := "<html>\n<head><title>foo</title></head>\n<body>bar</body>\n</html>"
+			if got := w.Body.String(); got != want {
+				t.Fatalf("got=%s, want=%s", got, want)
+			}
+		}
+
+		if calls != 1 {
+			t.Fatalf("ssr calls=%d, want=%d", calls, 1)
+		}
+
+		w, r := requestMock(http.MethodGet, "/")
+
+		if err := i.Render(w, r, "Some/Component", Props{"foo": "baz"}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if calls != 2 {
+			t.Fatalf("ssr calls=%d, want=%d", calls, 2)
+		}
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name      string
+			component string
+			only      []string
+			ctx       func(ctx context.Context) context.Context
+		}{
+			{
+				name:      "component is not cached",
+				component: "Dashboard",
+				only:      []string{"Public/*", "Home"},
+			},
+			{
+				name:      "disabled in context",
+				component: "Home",
+				ctx:       DisableSSRCache,
+			},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var calls int
+
+				cache := memorySSRCache(t, 10, 0)
+
+				i := I(func(i *Inertia) {
+					i.rootTemplateHTML = rootTemplate
+					i.ssrCache = cache
+					i.ssrCacheOnly = tt.only
+					i.ssr = ssrRendererFunc(func(context.Context, []byte) ([]string, string, error) {
+						calls++
+						return nil, "bar", nil
+					})
+				})
+
+				for range 2 {
+					w, r := requestMock(http.MethodGet, "/")
+					if tt.ctx != nil {
+						r = r.WithContext(tt.ctx(r.Context()))
+					}
+
+					if err := i.Render(w, r, tt.component); err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+				}
+
+				if calls != 2 {
+					t.Fatalf("ssr calls=%d, want=%d", calls, 2)
+				}
+
+				if cache.Len() != 0 {
+					t.Fatalf("len=%d, want=%d", cache.Len(), 0)
+				}
+			})
+		}
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		t.Parallel()
+
+		cache := memorySSRCache(t, 10, 0)
+
+		i := I(func(i *Inertia) {
+			i.ssrCache = cache
+			i.ssr = &ssrRendererMock{err: errors.New("foo")}
+		})
+
+		if _, _, err := i.renderSSRCached(context.Background(), []byte(`{}`)); err == nil {
+			t.Fatal("error expected")
+		}
+
+		if cache.Len() != 0 {
+			t.Fatalf("len=%d, want=%d", cache.Len(), 0)
+		}
+	})
+
+	t.Run("key depends on version", func(t *testing.T) {
+		t.Parallel()
+
+		i1 := I(func(i *Inertia) { i.version = "1" })
+		i2 := I(func(i *Inertia) { i.version = "2" })
+
+		if i1.ssrCacheKey([]byte(`{}`)) == i2.ssrCacheKey([]byte(`{}`)) {
+			t.Fatal("cache keys are equal for different versions")
+		}
+	})
+}
+
+func memorySSRCache(t *testing.T, maxSize int, ttl time.Duration) *MemorySSRCache {
+	t.Helper()
+
+	c, err := NewMemorySSRCache(maxSize, ttl)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return c
+}
@@ -0,0 +1,171 @@
+package gonertia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SSRHealthChecker is an optional interface of SSRRenderer, that checks the health of the SSR server.
+type SSRHealthChecker interface {
+	Health(ctx context.Context) error
+}
+
+var _ SSRHealthChecker = (*HTTPSSRRenderer)(nil)
+
+// Health sends request to the SSR health endpoint.
+func (s *HTTPSSRRenderer) Health(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.healthURL(), nil)
+	if err != nil {
+		return fmt.Errorf("new http request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("execute http request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("invalid response status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (s *HTTPSSRRenderer) healthURL() string {
+	return strings.ReplaceAll(s.url, "/render", "") + "/health"
+}
+
+// SSRHealthStatus is a result of the SSR health check.
+type SSRHealthStatus struct {
+	// Healthy reports whether the SSR server is alive.
+	Healthy bool `json:"healthy"`
+
+	// Latency is a duration of the health check request (in nanoseconds, when json marshaled).
+	Latency time.Duration `json:"latency"`
+
+	// Error is an error of the health check.
+	Error string `json:"error,omitempty"`
+
+	// LastError is the last error of the page rendering.
+	LastError string `json:"lastError,omitempty"`
+
+	// LastErrorAt is a time of the last error of the page rendering.
+	LastErrorAt time.Time `json:"lastErrorAt"`
+
+	// Fallbacks is a number of pages, that were rendered on the client side because SSR has failed.
+	Fallbacks uint64 `json:"fallbacks"`
+
+	// CircuitOpen reports whether SSR is skipped by the circuit breaker.
+	CircuitOpen bool `json:"circuitOpen"`
+}
+
+var errSSRDisabled = errors.New("ssr is disabled")
+
+// SSRHealth checks the health of the SSR server and returns its status
+// along with the page rendering statistics.
+//
+// Error is returned if SSR is disabled or the SSR server is not healthy.
+func (i *Inertia) SSRHealth(ctx context.Context) (SSRHealthStatus, error) {
+	status := i.ssrStats.status()
+
+	if i.ssrBreaker != nil {
+		status.CircuitOpen = i.ssrBreaker.isOpen()
+	}
+
+	err := i.checkSSRHealth(ctx, &status)
+	if err != nil {
+		status.Error = err.Error()
+		return status, err
+	}
+
+	status.Healthy = true
+
+	return status, nil
+}
+
+func (i *Inertia) checkSSRHealth(ctx context.Context, status *SSRHealthStatus) error {
+	if !i.isSSREnabled() {
+		return errSSRDisabled
+	}
+
+	checker, ok := i.ssr.(SSRHealthChecker)
+	if !ok {
+		return fmt.Errorf("ssr renderer %T doesn't support health checks", i.ssr)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, i.ssrTimeout())
+	defer cancel()
+
+	start := time.Now()
+	err := checker.Health(ctx)
+	status.Latency = time.Since(start)
+
+	if err != nil {
+		return fmt.Errorf("ssr health check: %w", err)
+	}
+
+	return nil
+}
+
+// SSRHealthHandler returns http.Handler, that can be used as the readiness or liveness probe.
+//
+// It responds with 200 status code if the SSR server is healthy, otherwise with 503.
+// Response body contains json marshaled SSRHealthStatus.
+func (i *Inertia) SSRHealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status, err := i.SSRHealth(r.Context())
+
+		js, marshalErr := i.jsonMarshaller.Marshal(status)
+		if marshalErr != nil {
+			http.Error(w, marshalErr.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		setJSONResponse(w)
+		if err != nil {
+			setResponseStatus(w, http.StatusServiceUnavailable)
+		}
+
+		if _, err = w.Write(js); err != nil {
+			i.logger.Printf("write ssr health response: %s", err)
+		}
+	})
+}
+
+// ssrStats collects the page rendering statistics for the health checks.
+type ssrStats struct {
+	mu          sync.Mutex
+	fallbacks   uint64
+	lastError   string
+	lastErrorAt time.Time
+}
+
+func (s *ssrStats) fallback(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fallbacks++
+
+	// Skipped renders are not errors.
+	if !errors.Is(err, errSSRCircuitOpen) {
+		s.lastError = err.Error()
+		s.lastErrorAt = time.Now()
+	}
+}
+
+func (s *ssrStats) status() SSRHealthStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return SSRHealthStatus{
+		Fallbacks:   s.fallbacks,
+		LastError:   s.lastError,
+		LastErrorAt: s.lastErrorAt,
+	}
+}
@@ -0,0 +1,200 @@
+package gonertia
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestInertia_SSRHealth(t *testing.T) {
+	t.Parallel()
+
+	t.Run("healthy", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/health" {
+				t.Fatalf("path=%s, want=%s", r.URL.Path, "/health")
+			}
+
+			_, _ = w.Write([]byte(`{"status":"OK"}`))
+		}))
+		defer srv.Close()
+
+		i := I(func(i *Inertia) {
+			i.ssr = NewHTTPSSRRenderer(srv.URL, srv.Client())
+		})
+
+		status, err := i.SSRHealth(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if !status.Healthy {
+			t.Fatal("ssr is not healthy")
+		}
+
+		if status.Latency <= 0 {
+			t.Fatalf("latency=%s, want positive", status.Latency)
+		}
+	})
+
+	t.Run("unhealthy", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		i := I(func(i *Inertia) {
+			i.ssr = NewHTTPSSRRenderer(srv.URL, srv.Client())
+		})
+
+		status, err := i.SSRHealth(context.Background())
+		if err == nil {
+			t.Fatal("error expected")
+		}
+
+		if status.Healthy {
+			t.Fatal("ssr is healthy")
+		}
+
+		if status.Error == "" {
+			t.Fatal("status error is empty")
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer srv.Close()
+
+		i := I(func(i *Inertia) {
+			i.ssrConfig = SSRConfig{Timeout: 50 * time.Millisecond}
+			i.ssr = NewHTTPSSRRenderer(srv.URL, srv.Client())
+		})
+
+		_, err := i.SSRHealth(context.Background())
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("error=%v, want=%v", err, context.DeadlineExceeded)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := I().SSRHealth(context.Background())
+		if !errors.Is(err, errSSRDisabled) {
+			t.Fatalf("error=%v, want=%v", err, errSSRDisabled)
+		}
+	})
+
+	t.Run("renderer without health checks", func(t *testing.T) {
+		t.Parallel()
+
+		i := I(func(i *Inertia) {
+			i.ssr = &ssrRendererMock{}
+		})
+
+		_, err := i.SSRHealth(context.Background())
+		if err == nil {
+			t.Fatal("error expected")
+		}
+	})
+
+	t.Run("fallbacks and last error", func(t *testing.T) {
+		t.Parallel()
+
+		i := I(func(i *Inertia) {
+			i.rootTemplateHTML = rootTemplate
+			i.ssr = &ssrRendererMock{err: errors.New("boom")}
+			i.ssrBreaker = newSSRCircuitBreaker(2, time.Minute)
+		})
+
+		for range 3 {
+			w, r := requestMock(http.MethodGet, "/")
+
+			if err := i.Render(w, r, "Some/Component"); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		}
+
+		status, _ := i.SSRHealth(context.Background())
+
+		if status.Fallbacks != 3 {
+			t.Fatalf("fallbacks=%d, want=%d", status.Fallbacks, 3)
+		}
+
+		if status.LastError != "boom" {
+			t.Fatalf("last error=%s, want=%s", status.LastError, "boom")
+		}
+
+		if status.LastErrorAt.IsZero() {
+			t.Fatal("last error time is zero")
+		}
+
+		if !status.CircuitOpen {
+			t.Fatal("circuit breaker is not open")
+		}
+	})
+}
+
+func TestInertia_SSRHealthHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		wantStatus int
+		wantHealth bool
+	}{
+		{"healthy", http.StatusOK, http.StatusOK, true},
+		{"unhealthy", http.StatusInternalServerError, http.StatusServiceUnavailable, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer srv.Close()
+
+			i := I(func(i *Inertia) {
+				i.ssr = NewHTTPSSRRenderer(srv.URL, srv.Client())
+			})
+
+			w, r := requestMock(http.MethodGet, "/health")
+
+			i.SSRHealthHandler().ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status=%d, want=%d", w.Code, tt.wantStatus)
+			}
+
+			if got := w.Header().Get("Content-Type"); got != "application/json" {
+				t.Fatalf("content type=%s, want=%s", got, "application/json")
+			}
+
+			var status SSRHealthStatus
+			if err := json.NewDecoder(w.Body).Decode(&status); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if status.Healthy != tt.wantHealth {
+				t.Fatalf("healthy=%t, want=%t", status.Healthy, tt.wantHealth)
+			}
+		})
+	}
+}
@@ -0,0 +1,371 @@
+package gonertia
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSSRURL                 = "http://127.0.0.1:13714"
+	defaultSSRProcessStartTimeout = 10 * time.Second
+	defaultSSRProcessMinBackoff   = 100 * time.Millisecond
+	defaultSSRProcessMaxBackoff   = 10 * time.Second
+	defaultSSRProcessStopTimeout  = 5 * time.Second
+)
+
+// SSRProcess is a supervisor of the SSR server process (e.g. "node bootstrap/ssr/ssr.mjs").
+//
+// It starts the process, waits until the server is ready, restarts it on crash
+// with exponential backoff, forwards its output to the logger
+// and stops it when the context passed to Start is canceled.
+type SSRProcess struct {
+	name string
+	args []string
+	dir  string
+	env  []string
+
+	url          string
+	startTimeout time.Duration
+	minBackoff   time.Duration
+	maxBackoff   time.Duration
+
+	logger Logger
+
+	httpClient *http.Client
+
+	mu      sync.Mutex
+	started bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// SSRProcessOption is an option parameter that modifies SSRProcess.
+type SSRProcessOption func(p *SSRProcess) error
+
+// NewSSRProcess initializes and returns SSRProcess, that runs SSR bundle with Node.js.
+func NewSSRProcess(bundlePath string, opts ...SSRProcessOption) (*SSRProcess, error) {
+	if bundlePath == "" {
+		return nil, fmt.Errorf("blank ssr bundle path")
+	}
+
+	p := &SSRProcess{
+		name:         "node",
+		args:         []string{bundlePath},
+		url:          defaultSSRURL,
+		startTimeout: defaultSSRProcessStartTimeout,
+		minBackoff:   defaultSSRProcessMinBackoff,
+		maxBackoff:   defaultSSRProcessMaxBackoff,
+		httpClient:   &http.Client{Timeout: time.Second},
+		done:         make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		if err := opt(p); err != nil {
+			return nil, fmt.Errorf("initialize ssr process: %w", err)
+		}
+	}
+
+	return p, nil
+}
+
+// WithSSRProcessCommand returns SSRProcessOption that will replace the default "node <bundle>" command.
+func WithSSRProcessCommand(name string, args ...string) SSRProcessOption {
+	return func(p *SSRProcess) error {
+		if name == "" {
+			return fmt.Errorf("blank command")
+		}
+
+		p.name = name
+		p.args = args
+		return nil
+	}
+}
+
+// WithSSRProcessDir returns SSRProcessOption that will set the working directory of the process.
+func WithSSRProcessDir(dir string) SSRProcessOption {
+	return func(p *SSRProcess) error {
+		p.dir = dir
+		return nil
+	}
+}
+
+// WithSSRProcessEnv returns SSRProcessOption that will set the environment of the process
+// (in "key=value" form). By default, the process inherits the environment of the current process.
+func WithSSRProcessEnv(env ...string) SSRProcessOption {
+	return func(p *SSRProcess) error {
+		p.env = env
+		return nil
+	}
+}
+
+// WithSSRProcessURL returns SSRProcessOption that will set the url, which SSR server listens to.
+// Default is http://127.0.0.1:13714.
+func WithSSRProcessURL(url string) SSRProcessOption {
+	return func(p *SSRProcess) error {
+		if url == "" {
+			return fmt.Errorf("blank url")
+		}
+
+		p.url = strings.TrimSuffix(strings.ReplaceAll(url, "/render", ""), "/")
+		return nil
+	}
+}
+
+// WithSSRProcessStartTimeout returns SSRProcessOption that will set
+// how long to wait for the SSR server to become ready.
+func WithSSRProcessStartTimeout(timeout time.Duration) SSRProcessOption {
+	return func(p *SSRProcess) error {
+		if timeout <= 0 {
+			return fmt.Errorf("invalid start timeout: %s", timeout)
+		}
+
+		p.startTimeout = timeout
+		return nil
+	}
+}
+
+// WithSSRProcessBackoff returns SSRProcessOption that will set min and max delays between restarts.
+func WithSSRProcessBackoff(minDelay, maxDelay time.Duration) SSRProcessOption {
+	return func(p *SSRProcess) error {
+		if minDelay <= 0 || maxDelay < minDelay {
+			return fmt.Errorf("invalid backoff: min=%s, max=%s", minDelay, maxDelay)
+		}
+
+		p.minBackoff = minDelay
+		p.maxBackoff = maxDelay
+		return nil
+	}
+}
+
+// WithSSRProcessLogger returns SSRProcessOption that will set the logger for the process output.
+// By default, the standard logger is used (see log.Default).
+func WithSSRProcessLogger(logger Logger) SSRProcessOption {
+	return func(p *SSRProcess) error {
+		if logger == nil {
+			return fmt.Errorf("nil logger")
+		}
+
+		p.logger = logger
+		return nil
+	}
+}
+
+// URL returns the url of the SSR server.
+func (p *SSRProcess) URL() string {
+	return p.url
+}
+
+// Done returns a channel that's closed when the process is stopped.
+func (p *SSRProcess) Done() <-chan struct{} {
+	return p.done
+}
+
+// Start starts the process and waits until the SSR server is ready.
+//
+// The process will be restarted on crash until the ctx is canceled.
+// If the server is not ready in time, or the process exits before that, the process is stopped
+// and an error is returned. If another server already responds at the url (e.g. the stale SSR server),
+// the process is not started.
+func (p *SSRProcess) Start(ctx context.Context) error {
+	p.mu.Lock()
+	if p.started {
+		p.mu.Unlock()
+		return fmt.Errorf("ssr process is already started")
+	}
+	// Otherwise, the new server fails to listen the address, and the old one is reported as ready.
+	if p.isReady(ctx) {
+		p.mu.Unlock()
+		return fmt.Errorf("ssr server is already running at %s", p.url)
+	}
+	p.started = true
+	ctx, p.cancel = context.WithCancel(ctx)
+	p.mu.Unlock()
+
+	exited := make(chan error, 1)
+
+	logger := p.getLogger()
+
+	go func() {
+		defer close(p.done)
+		p.supervise(ctx, logger, exited)
+	}()
+
+	if err := p.waitReady(ctx, exited); err != nil {
+		p.Stop()
+		return fmt.Errorf("wait for ssr server: %w", err)
+	}
+
+	return nil
+}
+
+// Stop stops the process and waits until it exits.
+func (p *SSRProcess) Stop() {
+	p.mu.Lock()
+	cancel := p.cancel
+	p.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	<-p.done
+}
+
+// supervise runs the process until the ctx is canceled.
+// The first exit of the process is sent to the exited channel.
+func (p *SSRProcess) supervise(ctx context.Context, logger Logger, exited chan<- error) {
+	backoff := p.minBackoff
+
+	for {
+		startedAt := time.Now()
+
+		err := p.run(ctx, logger)
+
+		select {
+		case exited <- err:
+		default:
+		}
+
+		if ctx.Err() != nil {
+			logger.Println("ssr process is stopped")
+			return
+		}
+
+		// The process was running for a while, so it's not a crash loop.
+		if time.Since(startedAt) > p.maxBackoff {
+			backoff = p.minBackoff
+		}
+
+		logger.Printf("ssr process exited: %v, restarting in %s", err, backoff)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			logger.Println("ssr process is stopped")
+			return
+		}
+
+		backoff = min(backoff*2, p.maxBackoff)
+	}
+}
+
+func (p *SSRProcess) run(ctx context.Context, logger Logger) error {
+	stdout := &logWriter{logger: logger, prefix: "ssr: "}
+	stderr := &logWriter{logger: logger, prefix: "ssr error: "}
+	defer stdout.flush()
+	defer stderr.flush()
+
+	cmd := exec.CommandContext(ctx, p.name, p.args...)
+	cmd.Dir = p.dir
+	cmd.Env = p.env
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	// Let the server shutdown gracefully, and kill it if it takes too long.
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = defaultSSRProcessStopTimeout
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start: %w", err)
+	}
+
+	return cmd.Wait()
+}
+
+// logWriter is an io.Writer, that writes every line to the logger.
+type logWriter struct {
+	logger Logger
+	prefix string
+	buf    []byte
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			break
+		}
+
+		w.logger.Println(w.prefix + string(w.buf[:idx]))
+		w.buf = w.buf[idx+1:]
+	}
+
+	return len(p), nil
+}
+
+func (w *logWriter) flush() {
+	if len(w.buf) > 0 {
+		w.logger.Println(w.prefix + string(w.buf))
+		w.buf = nil
+	}
+}
+
+// waitReady waits until the SSR server is ready.
+// If the process exits before that, the server, that responds at the url, is not the process one.
+func (p *SSRProcess) waitReady(ctx context.Context, exited <-chan error) error {
+	ctx, cancel := context.WithTimeout(ctx, p.startTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-exited:
+			return fmt.Errorf("ssr process exited: %v", err)
+		default:
+		}
+
+		if p.isReady(ctx) {
+			return nil
+		}
+
+		select {
+		case err := <-exited:
+			return fmt.Errorf("ssr process exited: %v", err)
+		case <-ticker.C:
+		case <-p.done:
+			return errors.New("ssr process is stopped")
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// isReady reports whether the SSR server accepts HTTP requests.
+// Any response except server errors is fine, because older SSR servers don't have the health endpoint.
+func (p *SSRProcess) isReady(ctx context.Context) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url+"/health", nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+
+	return resp.StatusCode < http.StatusInternalServerError
+}
+
+func (p *SSRProcess) getLogger() Logger {
+	if p.logger != nil {
+		return p.logger
+	}
+	return log.Default()
+}
@@ -0,0 +1,363 @@
+package gonertia
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestSSRProcessHelper is not a real test, it's used as the SSR server process by other tests.
+func TestSSRProcessHelper(t *testing.T) {
+	if os.Getenv("GONERTIA_SSR_HELPER") != "1" {
+		return
+	}
+
+	addr := os.Getenv("GONERTIA_SSR_HELPER_ADDR")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	})
+	mux.HandleFunc("/render", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"head":["<title>SSR</title>"],"body":"<div>SSR</div>"}`))
+	})
+	mux.HandleFunc("/crash", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(os.Stderr, "crashing")
+		os.Exit(1)
+	})
+
+	fmt.Println("listening on " + addr)
+
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
+
+func TestNewSSRProcess(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := NewSSRProcess(
+			"ssr.mjs",
+			WithSSRProcessDir("/app"),
+			WithSSRProcessURL("http://127.0.0.1:1234/render"),
+			WithSSRProcessStartTimeout(time.Second),
+			WithSSRProcessBackoff(time.Millisecond, time.Second),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if p.name != "node" || len(p.args) != 1 || p.args[0] != "ssr.mjs" {
+			t.Fatalf("command=%s %v, want=%s", p.name, p.args, "node ssr.mjs")
+		}
+
+		if p.dir != "/app" {
+			t.Fatalf("dir=%s, want=%s", p.dir, "/app")
+		}
+
+		if p.URL() != "http://127.0.0.1:1234" {
+			t.Fatalf("url=%s, want=%s", p.URL(), "http://127.0.0.1:1234")
+		}
+
+		if p.startTimeout != time.Second {
+			t.Fatalf("start timeout=%s, want=%s", p.startTimeout, time.Second)
+		}
+
+		if p.minBackoff != time.Millisecond || p.maxBackoff != time.Second {
+			t.Fatalf("backoff=%s..%s, want=%s..%s", p.minBackoff, p.maxBackoff, time.Millisecond, time.Second)
+		}
+
+		if p.getLogger() != log.Default() {
+			t.Fatal("default logger is not the standard logger")
+		}
+	})
+
+	tests := map[string]struct {
+		bundlePath string
+		opts       []SSRProcessOption
+	}{
+		"blank bundle path":     {"", nil},
+		"blank command":         {"ssr.mjs", []SSRProcessOption{WithSSRProcessCommand("")}},
+		"blank url":             {"ssr.mjs", []SSRProcessOption{WithSSRProcessURL("")}},
+		"invalid start timeout": {"ssr.mjs", []SSRProcessOption{WithSSRProcessStartTimeout(0)}},
+		"invalid backoff":       {"ssr.mjs", []SSRProcessOption{WithSSRProcessBackoff(time.Second, time.Millisecond)}},
+		"nil logger":            {"ssr.mjs", []SSRProcessOption{WithSSRProcessLogger(nil)}},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewSSRProcess(tt.bundlePath, tt.opts...)
+			if err == nil {
+				t.Fatal("error expected")
+			}
+		})
+	}
+}
+
+func TestSSRProcess(t *testing.T) {
+	t.Parallel()
+
+	t.Run("start and stop", func(t *testing.T) {
+		t.Parallel()
+
+		logs := new(syncBuffer)
+		p := ssrProcessHelper(t, WithSSRProcessLogger(log.New(logs, "", 0)))
+
+		if err := p.Start(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		head, body, err := NewHTTPSSRRenderer(p.URL(), &http.Client{}).Render(context.Background(), []byte(`{}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if len(head) != 1 || head[0] != "<title>SSR</title>" || body != "<div>SSR</div>" {
+			t.Fatalf("head=%v, body=%s", head, body)
+		}
+
+		p.Stop()
+
+		select {
+		case <-p.Done():
+		default:
+			t.Fatal("process is not stopped")
+		}
+
+		if !strings.Contains(logs.String(), "ssr: listening on ") {
+			t.Fatalf("process output is not logged, logs=%s", logs.String())
+		}
+	})
+
+	t.Run("restart on crash", func(t *testing.T) {
+		t.Parallel()
+
+		logs := new(syncBuffer)
+		p := ssrProcessHelper(t, WithSSRProcessLogger(log.New(logs, "", 0)))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer func() {
+			cancel()
+			<-p.Done()
+		}()
+
+		if err := p.Start(ctx); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		resp, err := http.Get(p.URL() + "/crash")
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+
+		deadline := time.Now().Add(5 * time.Second)
+		for !strings.Contains(logs.String(), "ssr process exited") {
+			if time.Now().After(deadline) {
+				t.Fatalf("process crash is not logged, logs=%s", logs.String())
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if err = p.waitReady(ctx, nil); err != nil {
+			t.Fatalf("process is not restarted: %s", err)
+		}
+
+		if !strings.Contains(logs.String(), "ssr error: crashing") {
+			t.Fatalf("process errors are not logged, logs=%s", logs.String())
+		}
+	})
+
+	t.Run("start timeout", func(t *testing.T) {
+		t.Parallel()
+
+		// The process is never ready, because it serves another address.
+		p, err := NewSSRProcess(
+			"ssr.mjs",
+			WithSSRProcessCommand(os.Args[0], "-test.run=^TestSSRProcessHelper$"),
+			WithSSRProcessEnv("GONERTIA_SSR_HELPER=1", "GONERTIA_SSR_HELPER_ADDR="+freeAddr(t)),
+			WithSSRProcessURL("http://"+freeAddr(t)),
+			WithSSRProcessStartTimeout(200*time.Millisecond),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if err = p.Start(context.Background()); err == nil {
+			t.Fatal("error expected")
+		}
+
+		select {
+		case <-p.Done():
+		default:
+			t.Fatal("process is not stopped")
+		}
+	})
+
+	t.Run("exited before ready", func(t *testing.T) {
+		t.Parallel()
+
+		// The process fails to listen the invalid address and exits.
+		p, err := NewSSRProcess(
+			"ssr.mjs",
+			WithSSRProcessCommand(os.Args[0], "-test.run=^TestSSRProcessHelper$"),
+			WithSSRProcessEnv("GONERTIA_SSR_HELPER=1", "GONERTIA_SSR_HELPER_ADDR=invalid"),
+			WithSSRProcessURL("http://"+freeAddr(t)),
+			WithSSRProcessStartTimeout(time.Minute),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		err = p.Start(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "ssr process exited") {
+			t.Fatalf("error=%v, want process exited error", err)
+		}
+
+		select {
+		case <-p.Done():
+		default:
+			t.Fatal("process is not stopped")
+		}
+	})
+
+	t.Run("another server is running", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer srv.Close()
+
+		p, err := NewSSRProcess(
+			"ssr.mjs",
+			WithSSRProcessCommand(os.Args[0], "-test.run=^TestSSRProcessHelper$"),
+			WithSSRProcessURL(srv.URL),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if err = p.Start(context.Background()); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+
+	t.Run("already started", func(t *testing.T) {
+		t.Parallel()
+
+		p := ssrProcessHelper(t)
+
+		if err := p.Start(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		defer p.Stop()
+
+		if err := p.Start(context.Background()); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
+
+func TestSSRProcess_isReady(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status int
+		want   bool
+	}{
+		{"ok", http.StatusOK, true},
+		{"no health endpoint", http.StatusNotFound, true},
+		{"server error", http.StatusInternalServerError, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			p, err := NewSSRProcess("ssr.mjs", WithSSRProcessURL(srv.URL))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := p.isReady(context.Background()); got != tt.want {
+				t.Fatalf("ready=%t, want=%t", got, tt.want)
+			}
+		})
+	}
+}
+
+func ssrProcessHelper(t *testing.T, opts ...SSRProcessOption) *SSRProcess {
+	t.Helper()
+
+	addr := freeAddr(t)
+
+	p, err := NewSSRProcess(
+		"ssr.mjs",
+		append([]SSRProcessOption{
+			WithSSRProcessCommand(os.Args[0], "-test.run=^TestSSRProcessHelper$"),
+			WithSSRProcessEnv("GONERTIA_SSR_HELPER=1", "GONERTIA_SSR_HELPER_ADDR="+addr),
+			WithSSRProcessURL("http://" + addr),
+			WithSSRProcessBackoff(10*time.Millisecond, time.Second),
+		}, opts...)...,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return p
+}
+
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer l.Close()
+
+	return l.Addr().String()
+}
+
+// syncBuffer is a bytes.Buffer, that is safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,270 @@
+package gonertia
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewHTTPSSRRenderer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default client", func(t *testing.T) {
+		t.Parallel()
+
+		if renderer := NewHTTPSSRRenderer(defaultSSRURL, nil); renderer.client != http.DefaultClient {
+			t.Fatal("client is not http.DefaultClient")
+		}
+	})
+
+	t.Run("custom client", func(t *testing.T) {
+		t.Parallel()
+
+		client := &http.Client{}
+
+		if renderer := NewHTTPSSRRenderer(defaultSSRURL, client); renderer.client != client {
+			t.Fatal("client is not the passed one")
+		}
+	})
+}
+
+func TestHTTPSSRRenderer_Render(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/render" {
+				t.Fatalf("path=%s, want=%s", r.URL.Path, "/render")
+			}
+
+			setJSONResponse(w)
+			_, _ = w.Write([]byte(`{"head":["<title>foo</title>"],"body":"<div>bar</div>"}`))
+		}))
+		defer ts.Close()
+
+		head, body, err := NewHTTPSSRRenderer(ts.URL+"/render", ts.Client()).Render(context.Background(), []byte(`{}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if want := []string{"<title>foo</title>"}; !reflect.DeepEqual(head, want) {
+			t.Fatalf("head=%#v, want=%#v", head, want)
+		}
+
+		if want := "<div>bar</div>"; body != want {
+			t.Fatalf("body=%s, want=%s", body, want)
+		}
+	})
+
+	t.Run("invalid status code", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer ts.Close()
+
+		_, _, err := NewHTTPSSRRenderer(ts.URL, ts.Client()).Render(context.Background(), []byte(`{}`))
+		if err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
+
+func TestInertia_renderSSR(t *testing.T) {
+	t.Parallel()
+
+	t.Run("retries", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+
+		i := I(func(i *Inertia) {
+			i.ssrConfig = SSRConfig{Retries: 2}
+			i.ssr = ssrRendererFunc(func(context.Context, []byte) ([]string, string, error) {
+				calls++
+				if calls < 3 {
+					return nil, "", errors.New("foo")
+				}
+				return nil, "bar", nil
+			})
+		})
+
+		_, body, err := i.renderSSR(context.Background(), []byte(`{}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if body != "bar" {
+			t.Fatalf("body=%s, want=%s", body, "bar")
+		}
+
+		if calls != 3 {
+			t.Fatalf("calls=%d, want=%d", calls, 3)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+
+		i := I(func(i *Inertia) {
+			i.ssrConfig = SSRConfig{Timeout: time.Millisecond}
+			i.ssr = ssrRendererFunc(func(ctx context.Context, _ []byte) ([]string, string, error) {
+				<-ctx.Done()
+				return nil, "", ctx.Err()
+			})
+		})
+
+		_, _, err := i.renderSSR(context.Background(), []byte(`{}`))
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("error=%v, want=%v", err, context.DeadlineExceeded)
+		}
+	})
+
+	t.Run("timeout deadline", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name    string
+			timeout time.Duration
+			want    time.Duration
+		}{
+			{"default", 0, defaultSSRTimeout},
+			{"above default", 30 * time.Second, 30 * time.Second},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				var got time.Duration
+
+				i := I(func(i *Inertia) {
+					i.ssrConfig = SSRConfig{Timeout: tt.timeout}
+					i.ssr = ssrRendererFunc(func(ctx context.Context, _ []byte) ([]string, string, error) {
+						deadline, _ := ctx.Deadline()
+						got = time.Until(deadline)
+						return nil, "bar", nil
+					})
+				})
+
+				if _, _, err := i.renderSSR(context.Background(), []byte(`{}`)); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if got <= tt.want-time.Second || got > tt.want {
+					t.Fatalf("timeout=%s, want=%s", got, tt.want)
+				}
+			})
+		}
+
+		// Http client must not limit the timeout from the config.
+		renderer := I().newHTTPSSRRenderer(defaultSSRURL)
+		if renderer.client.Timeout != 0 {
+			t.Fatalf("client timeout=%s, want=0", renderer.client.Timeout)
+		}
+	})
+
+	t.Run("circuit breaker", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+		fail := true
+
+		i := I(func(i *Inertia) {
+			i.ssrConfig = SSRConfig{FailureThreshold: 2, CoolDown: time.Minute}
+			i.ssrBreaker = newSSRCircuitBreaker(2, time.Minute)
+			i.ssr = ssrRendererFunc(func(context.Context, []byte) ([]string, string, error) {
+				calls++
+				if fail {
+					return nil, "", errors.New("foo")
+				}
+				return nil, "bar", nil
+			})
+		})
+
+		now := time.Now()
+		i.ssrBreaker.now = func() time.Time { return now }
+
+		for range 3 {
+			_, _, _ = i.renderSSR(context.Background(), []byte(`{}`))
+		}
+
+		if calls != 2 {
+			t.Fatalf("calls=%d, want=%d", calls, 2)
+		}
+
+		_, _, err := i.renderSSR(context.Background(), []byte(`{}`))
+		if !errors.Is(err, errSSRCircuitOpen) {
+			t.Fatalf("error=%v, want=%v", err, errSSRCircuitOpen)
+		}
+
+		// Cool down is over, SSR works again.
+		now = now.Add(time.Minute)
+		fail = false
+
+		_, body, err := i.renderSSR(context.Background(), []byte(`{}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if body != "bar" {
+			t.Fatalf("body=%s, want=%s", body, "bar")
+		}
+
+		if i.ssrBreaker.state != ssrCircuitClosed {
+			t.Fatalf("circuit breaker state=%d, want=%d", i.ssrBreaker.state, ssrCircuitClosed)
+		}
+	})
+}
+
+func Test_ssrCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("half-open failure opens again", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+
+		b := newSSRCircuitBreaker(1, time.Minute)
+		b.now = func() time.Time { return now }
+
+		if opened := b.failure(); !opened {
+			t.Fatal("expected circuit breaker to be opened")
+		}
+
+		now = now.Add(time.Minute)
+
+		allowed, halfOpened := b.allow()
+		if !allowed || !halfOpened {
+			t.Fatalf("allowed=%t, halfOpened=%t, want both true", allowed, halfOpened)
+		}
+
+		// Only one trial render at the same time.
+		if allowed, _ = b.allow(); allowed {
+			t.Fatal("expected render to be not allowed during trial")
+		}
+
+		if opened := b.failure(); !opened {
+			t.Fatal("expected circuit breaker to be opened again")
+		}
+
+		if allowed, _ = b.allow(); allowed {
+			t.Fatal("expected render to be not allowed")
+		}
+	})
+}
+
+type ssrRendererFunc func(ctx context.Context, pageJSON []byte) ([]string, string, error)
+
+func (f ssrRendererFunc) Render(ctx context.Context, pageJSON []byte) ([]string, string, error) {
+	return f(ctx, pageJSON)
+}
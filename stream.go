@@ -0,0 +1,80 @@
+package gonertia
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Placeholders of the page html, that are replaced after the root template head is flushed.
+const (
+	streamInertiaPlaceholder     = "<!--gonertia:inertia-->"
+	streamInertiaHeadPlaceholder = "<!--gonertia:inertiaHead-->"
+)
+
+// doStreamingHTMLResponse executes the root template with placeholders instead of the page html,
+// flushes everything before the first placeholder, and only then builds the page
+// and writes the rest of the response.
+func (i *Inertia) doStreamingHTMLResponse(w http.ResponseWriter, r *http.Request, component string, props Props) error {
+	rootTemplate, err := i.getRootTemplate(i.rootTemplateNameFor(r.Context(), component))
+	if err != nil {
+		return fmt.Errorf("get root template: %w", err)
+	}
+
+	templateData := i.templateDataWith(r, streamInertiaPlaceholder, streamInertiaHeadPlaceholder)
+
+	var buf bytes.Buffer
+	if err = rootTemplate.Execute(&buf, templateData); err != nil {
+		return fmt.Errorf("execute root template: %w", err)
+	}
+
+	head, tail := splitStreamedHTML(buf.String())
+
+	setHTMLResponse(w)
+
+	if _, err = w.Write([]byte(head)); err != nil {
+		return fmt.Errorf("write html head: %w", err)
+	}
+
+	if err = http.NewResponseController(w).Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return fmt.Errorf("flush html head: %w", err)
+	}
+
+	p, err := i.buildPage(r, component, props)
+	if err != nil {
+		return fmt.Errorf("build page: %w", err)
+	}
+
+	inertia, inertiaHead, err := i.buildInertiaHTML(r, p)
+	if err != nil {
+		return fmt.Errorf("build inertia html: %w", err)
+	}
+
+	replacer := strings.NewReplacer(
+		streamInertiaPlaceholder, string(inertia),
+		streamInertiaHeadPlaceholder, string(inertiaHead),
+	)
+	if _, err = replacer.WriteString(w, tail); err != nil {
+		return fmt.Errorf("write html body: %w", err)
+	}
+
+	return nil
+}
+
+// splitStreamedHTML splits html at the first placeholder, so the head can be sent before the page is built.
+func splitStreamedHTML(html string) (head, tail string) {
+	idx := -1
+	for _, placeholder := range []string{streamInertiaPlaceholder, streamInertiaHeadPlaceholder} {
+		if i := strings.Index(html, placeholder); i >= 0 && (idx < 0 || i < idx) {
+			idx = i
+		}
+	}
+
+	if idx < 0 {
+		return html, ""
+	}
+
+	return html[:idx], html[idx:]
+}
@@ -0,0 +1,159 @@
+package gonertia
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestInertia_Render_streaming(t *testing.T) {
+	t.Parallel()
+
+	t.Run("flushes head before props resolution", func(t *testing.T) {
+		t.Parallel()
+
+		i := I(func(i *Inertia) {
+			i.rootTemplateHTML = rootTemplate
+			i.streaming = true
+		})
+
+		w, r := requestMock(http.MethodGet, "/home")
+
+		var flushedHead string
+		err := i.Render(w, r, "Some/Component", Props{
+			"foo": func() (any, error) {
+				if !w.Flushed {
+					return nil, errors.New("head is not flushed")
+				}
+				flushedHead = w.Body.String()
+				return "bar", nil
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if want := "<html>\n<head>"; flushedHead != want {
+			t.Fatalf("flushed head=%q, want=%q", flushedHead, want)
+		}
+
+		want := `<html>
+<head></head>
+<body><div id="app" data-page="{&#34;component&#34;:&#34;Some/Component&#34;,&#34;props&#34;:{&#34;errors&#34;:{},&#34;foo&#34;:&#34;bar&#34;},&#34;url&#34;:&#34;/home&#34;,&#34;version&#34;:&#34;&#34;,&#34;encryptHistory&#34;:false,&#34;clearHistory&#34;:false}"></div></body>
+</html>`
+		if got := w.Body.String(); got != want {
+			t.Fatalf("got=%s, want=%s", got, want)
+		}
+
+		assertHTMLResponse(t, w)
+	})
+
+	t.Run("ssr head", func(t *testing.T) {
+		t.Parallel()
+
+		i := I(func(i *Inertia) {
+			i.rootTemplateHTML = rootTemplate
+			i.streaming = true
+			i.ssr = &ssrRendererMock{
+				head: []string{`<title inertia>foo</title>`},
+				body: `<div id="app">foo bar</div>`,
+			}
+		})
+
+		w, r := requestMock(http.MethodGet, "/home")
+
+		if err := i.Render(w, r, "Some/Component"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := "<html>\n<head><title inertia>foo</title></head>\n<body><div id=\"app\">foo bar</div></body>\n</html>"
+		if got := w.Body.String(); got != want {
+			t.Fatalf("got=%s, want=%s", got, want)
+		}
+	})
+
+	t.Run("inertia request", func(t *testing.T) {
+		t.Parallel()
+
+		i := I(func(i *Inertia) {
+			i.rootTemplateHTML = rootTemplate
+			i.streaming = true
+		})
+
+		w, r := requestMock(http.MethodGet, "/home")
+		asInertiaRequest(r)
+
+		if err := i.Render(w, r, "Some/Component"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		assertInertiaResponse(t, w)
+		AssertFromString(t, w.Body.String()).AssertComponent("Some/Component")
+	})
+
+	t.Run("props error after flush", func(t *testing.T) {
+		t.Parallel()
+
+		i := I(func(i *Inertia) {
+			i.rootTemplateHTML = rootTemplate
+			i.streaming = true
+		})
+
+		w, r := requestMock(http.MethodGet, "/home")
+
+		err := i.Render(w, r, "Some/Component", Props{
+			"foo": func() (any, error) { return nil, errors.New("foo") },
+		})
+		if err == nil {
+			t.Fatal("error expected")
+		}
+
+		if !w.Flushed {
+			t.Fatal("head is not flushed")
+		}
+	})
+}
+
+func Test_splitStreamedHTML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		html     string
+		wantHead string
+		wantTail string
+	}{
+		{
+			"head placeholder first",
+			"<head>" + streamInertiaHeadPlaceholder + "</head><body>" + streamInertiaPlaceholder + "</body>",
+			"<head>",
+			streamInertiaHeadPlaceholder + "</head><body>" + streamInertiaPlaceholder + "</body>",
+		},
+		{
+			"only inertia placeholder",
+			"<head></head><body>" + streamInertiaPlaceholder + "</body>",
+			"<head></head><body>",
+			streamInertiaPlaceholder + "</body>",
+		},
+		{
+			"without placeholders",
+			"<head></head>",
+			"<head></head>",
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			head, tail := splitStreamedHTML(tt.html)
+
+			if head != tt.wantHead || tail != tt.wantTail {
+				t.Fatalf("head=%q, tail=%q, want head=%q, tail=%q", head, tail, tt.wantHead, tt.wantTail)
+			}
+		})
+	}
+}
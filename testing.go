@@ -65,7 +65,60 @@ func (i AssertableInertia) AssertProps(want Props) {
 	}
 }
 
-var containerRe = regexp.MustCompile(` data-page="(.*?)"`)
+// AssertEncryptHistory verifies that encrypt history flag from Inertia
+// response and the passed value are the same.
+func (i AssertableInertia) AssertEncryptHistory(want bool) {
+	i.t.Helper()
+
+	if i.EncryptHistory != want {
+		i.t.Fatalf("inertia: EncryptHistory=%t, want=%t", i.EncryptHistory, want)
+	}
+}
+
+// AssertClearHistory verifies that clear history flag from Inertia
+// response and the passed value are the same.
+func (i AssertableInertia) AssertClearHistory(want bool) {
+	i.t.Helper()
+
+	if i.ClearHistory != want {
+		i.t.Fatalf("inertia: ClearHistory=%t, want=%t", i.ClearHistory, want)
+	}
+}
+
+// AssertDeferredProps verifies that deferred props from Inertia
+// response and the passed deferred props are the same.
+func (i AssertableInertia) AssertDeferredProps(want map[string][]string) {
+	i.t.Helper()
+
+	if !reflect.DeepEqual(i.DeferredProps, want) {
+		i.t.Fatalf("inertia: DeferredProps=%#v, want=%#v", i.DeferredProps, want)
+	}
+}
+
+// AssertMergeProps verifies that merge props from Inertia
+// response and the passed merge props are the same.
+func (i AssertableInertia) AssertMergeProps(want []string) {
+	i.t.Helper()
+
+	if !reflect.DeepEqual(i.MergeProps, want) {
+		i.t.Fatalf("inertia: MergeProps=%#v, want=%#v", i.MergeProps, want)
+	}
+}
+
+// AssertDeepMergeProps verifies that deep merge props from Inertia
+// response and the passed deep merge props are the same.
+func (i AssertableInertia) AssertDeepMergeProps(want []string) {
+	i.t.Helper()
+
+	if !reflect.DeepEqual(i.DeepMergeProps, want) {
+		i.t.Fatalf("inertia: DeepMergeProps=%#v, want=%#v", i.DeepMergeProps, want)
+	}
+}
+
+var (
+	containerRe       = regexp.MustCompile(` data-page="(.*?)"`)
+	scriptContainerRe = regexp.MustCompile(`(?s)<script[^>]* data-page="[^"]*"[^>]*>(.*?)</script>`)
+)
 
 // AssertFromReader creates AssertableInertia from the io.Reader body.
 func AssertFromReader(t t, body io.Reader) AssertableInertia {
@@ -100,6 +153,14 @@ func AssertFromBytes(t t, body []byte) AssertableInertia {
 		return assertable
 	}
 
+	// Page might be in the json script element.
+	for _, m := range scriptContainerRe.FindAllStringSubmatch(buf.String(), -1) {
+		if err := json.Unmarshal([]byte(m[1]), &assertable.page); err == nil {
+			assertable.Body = buf
+			return assertable
+		}
+	}
+
 	matched := containerRe.FindAllStringSubmatch(buf.String(), -1)
 	if len(matched) == 0 {
 		invalidInertiaResponse(t)
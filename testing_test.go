@@ -42,6 +42,16 @@ const stubHTML = `<!DOCTYPE html>
 	</body>
 </html>`
 
+const stubScriptHTML = `<!DOCTYPE html>
+<html lang="en">
+	<head>
+            <script type="module" src="/build/assets/main.js"></script>
+	</head>
+	<body>
+		<script data-page="app" type="application/json">{"component":"Foo/Bar","props":{"foo": "bar"},"url":"https://example.com","version":"foobar"}</script><div id="app"></div>
+	</body>
+</html>`
+
 const stubJSON = `{"component":"Foo/Bar","props":{"foo": "bar"},"url":"https://example.com","version":"foobar"}`
 
 func TestAssertableInertia_AssertComponent(t *testing.T) {
@@ -228,6 +238,236 @@ func TestAssertableInertia_AssertProps(t *testing.T) {
 	})
 }
 
+func TestAssertableInertia_AssertEncryptHistory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("positive", func(t *testing.T) {
+		t.Parallel()
+
+		mock := new(tMock)
+
+		i := AssertableInertia{
+			t:    mock,
+			page: &page{EncryptHistory: true},
+		}
+
+		i.AssertEncryptHistory(true)
+
+		if !mock.helperInvoked {
+			t.Fatal("expected Helper() to be invoked")
+		}
+
+		if mock.isFailed {
+			t.Fatal("unexpected assertion failure")
+		}
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		t.Parallel()
+
+		mock := new(tMock)
+
+		i := AssertableInertia{
+			t:    mock,
+			page: &page{EncryptHistory: true},
+		}
+
+		i.AssertEncryptHistory(false)
+
+		if !mock.helperInvoked {
+			t.Fatal("expected Helper() to be invoked")
+		}
+
+		if !mock.isFailed {
+			t.Fatal("expected assertion failure")
+		}
+	})
+}
+
+func TestAssertableInertia_AssertClearHistory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("positive", func(t *testing.T) {
+		t.Parallel()
+
+		mock := new(tMock)
+
+		i := AssertableInertia{
+			t:    mock,
+			page: &page{ClearHistory: true},
+		}
+
+		i.AssertClearHistory(true)
+
+		if !mock.helperInvoked {
+			t.Fatal("expected Helper() to be invoked")
+		}
+
+		if mock.isFailed {
+			t.Fatal("unexpected assertion failure")
+		}
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		t.Parallel()
+
+		mock := new(tMock)
+
+		i := AssertableInertia{
+			t:    mock,
+			page: &page{ClearHistory: true},
+		}
+
+		i.AssertClearHistory(false)
+
+		if !mock.helperInvoked {
+			t.Fatal("expected Helper() to be invoked")
+		}
+
+		if !mock.isFailed {
+			t.Fatal("expected assertion failure")
+		}
+	})
+}
+
+func TestAssertableInertia_AssertDeferredProps(t *testing.T) {
+	t.Parallel()
+
+	t.Run("positive", func(t *testing.T) {
+		t.Parallel()
+
+		mock := new(tMock)
+
+		i := AssertableInertia{
+			t:    mock,
+			page: &page{DeferredProps: map[string][]string{"default": {"foo"}}},
+		}
+
+		i.AssertDeferredProps(map[string][]string{"default": {"foo"}})
+
+		if !mock.helperInvoked {
+			t.Fatal("expected Helper() to be invoked")
+		}
+
+		if mock.isFailed {
+			t.Fatal("unexpected assertion failure")
+		}
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		t.Parallel()
+
+		mock := new(tMock)
+
+		i := AssertableInertia{
+			t:    mock,
+			page: &page{DeferredProps: map[string][]string{"default": {"foo"}}},
+		}
+
+		i.AssertDeferredProps(map[string][]string{"other": {"foo"}})
+
+		if !mock.helperInvoked {
+			t.Fatal("expected Helper() to be invoked")
+		}
+
+		if !mock.isFailed {
+			t.Fatal("expected assertion failure")
+		}
+	})
+}
+
+func TestAssertableInertia_AssertMergeProps(t *testing.T) {
+	t.Parallel()
+
+	t.Run("positive", func(t *testing.T) {
+		t.Parallel()
+
+		mock := new(tMock)
+
+		i := AssertableInertia{
+			t:    mock,
+			page: &page{MergeProps: []string{"foo"}},
+		}
+
+		i.AssertMergeProps([]string{"foo"})
+
+		if !mock.helperInvoked {
+			t.Fatal("expected Helper() to be invoked")
+		}
+
+		if mock.isFailed {
+			t.Fatal("unexpected assertion failure")
+		}
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		t.Parallel()
+
+		mock := new(tMock)
+
+		i := AssertableInertia{
+			t:    mock,
+			page: &page{MergeProps: []string{"foo"}},
+		}
+
+		i.AssertMergeProps([]string{"bar"})
+
+		if !mock.helperInvoked {
+			t.Fatal("expected Helper() to be invoked")
+		}
+
+		if !mock.isFailed {
+			t.Fatal("expected assertion failure")
+		}
+	})
+}
+
+func TestAssertableInertia_AssertDeepMergeProps(t *testing.T) {
+	t.Parallel()
+
+	t.Run("positive", func(t *testing.T) {
+		t.Parallel()
+
+		mock := new(tMock)
+
+		i := AssertableInertia{
+			t:    mock,
+			page: &page{DeepMergeProps: []string{"foo"}},
+		}
+
+		i.AssertDeepMergeProps([]string{"foo"})
+
+		if !mock.helperInvoked {
+			t.Fatal("expected Helper() to be invoked")
+		}
+
+		if mock.isFailed {
+			t.Fatal("unexpected assertion failure")
+		}
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		t.Parallel()
+
+		mock := new(tMock)
+
+		i := AssertableInertia{
+			t:    mock,
+			page: &page{DeepMergeProps: []string{"foo"}},
+		}
+
+		i.AssertDeepMergeProps([]string{"bar"})
+
+		if !mock.helperInvoked {
+			t.Fatal("expected Helper() to be invoked")
+		}
+
+		if !mock.isFailed {
+			t.Fatal("expected assertion failure")
+		}
+	})
+}
+
 func TestAssertFromString(t *testing.T) {
 	t.Parallel()
 
@@ -288,6 +528,16 @@ func TestAssertFromString(t *testing.T) {
 
 		assertStubSuccess(t, mock, stubHTML, assertable)
 	})
+
+	t.Run("success with script element", func(t *testing.T) {
+		t.Parallel()
+
+		mock := new(tMock)
+
+		assertable := AssertFromString(mock, stubScriptHTML)
+
+		assertStubSuccess(t, mock, stubScriptHTML, assertable)
+	})
 }
 
 func TestAssertFromBytes(t *testing.T) {
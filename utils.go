@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"io"
 	"os"
+	"strings"
 )
 
 func setOf[T comparable](data []T) map[T]struct{} {
@@ -27,6 +28,25 @@ func firstOr[T any](items []T, fallback T) T {
 	return fallback
 }
 
+// matchComponent reports whether the component matches any of the patterns.
+// Pattern is either a component name or a prefix with trailing "*" (e.g. "Admin/*").
+func matchComponent(component string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(component, prefix) {
+				return true
+			}
+			continue
+		}
+
+		if component == pattern {
+			return true
+		}
+	}
+
+	return false
+}
+
 func md5(str string) string {
 	hash := crypto.Sum([]byte(str))
 	return hex.EncodeToString(hash[:])
@@ -161,3 +161,33 @@ func Test_md5File(t *testing.T) {
 		t.Fatalf("md5File()=%s, want=%s", got, want)
 	}
 }
+
+func Test_matchComponent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		component string
+		patterns  []string
+		want      bool
+	}{
+		{"no patterns", "Foo/Bar", nil, false},
+		{"exact match", "Foo/Bar", []string{"Baz", "Foo/Bar"}, true},
+		{"exact mismatch", "Foo/Bar", []string{"Foo"}, false},
+		{"prefix match", "Foo/Bar/Baz", []string{"Foo/*"}, true},
+		{"prefix mismatch", "Bar/Foo", []string{"Foo/*"}, false},
+		{"wildcard", "Foo", []string{"*"}, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := matchComponent(tt.component, tt.patterns); got != tt.want {
+				t.Fatalf("matchComponent()=%t, want=%t", got, tt.want)
+			}
+		})
+	}
+}
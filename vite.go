@@ -0,0 +1,270 @@
+package gonertia
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path"
+	"strings"
+)
+
+const (
+	defaultViteBuildURL = "/build/"
+	viteClientEntry     = "@vite/client"
+)
+
+// Vite is an integration with Vite (https://vitejs.dev/), that renders
+// script, stylesheet and module preload tags of the entries from the build manifest.
+//
+// In development mode, tags point to the Vite dev server instead.
+type Vite struct {
+	manifestPath string
+	manifest     map[string]viteChunk
+	version      string
+
+	buildURL     string
+	devServerURL string
+}
+
+// viteChunk is a chunk of the Vite build manifest.
+//
+// https://vitejs.dev/guide/backend-integration.html
+type viteChunk struct {
+	File    string   `json:"file"`
+	Src     string   `json:"src"`
+	IsEntry bool     `json:"isEntry"`
+	Imports []string `json:"imports"`
+	CSS     []string `json:"css"`
+}
+
+// ViteOption is an option parameter that modifies Vite.
+type ViteOption func(v *Vite) error
+
+// NewVite initializes and returns Vite, that reads the build manifest
+// (e.g. "public/build/.vite/manifest.json").
+//
+// Manifest is not read in development mode.
+func NewVite(manifestPath string, opts ...ViteOption) (*Vite, error) {
+	v := &Vite{
+		manifestPath: manifestPath,
+		buildURL:     defaultViteBuildURL,
+	}
+
+	for _, opt := range opts {
+		if err := opt(v); err != nil {
+			return nil, fmt.Errorf("initialize vite: %w", err)
+		}
+	}
+
+	if v.IsDev() {
+		return v, nil
+	}
+
+	if err := v.readManifest(); err != nil {
+		return nil, fmt.Errorf("initialize vite: %w", err)
+	}
+
+	return v, nil
+}
+
+// WithViteBuildURL returns ViteOption that will set the public url of the build directory.
+// Default is "/build/".
+func WithViteBuildURL(url string) ViteOption {
+	return func(v *Vite) error {
+		v.buildURL = strings.TrimSuffix(url, "/") + "/"
+		return nil
+	}
+}
+
+// WithViteDevServer returns ViteOption that will enable development mode,
+// so the assets will be served by the Vite dev server (e.g. "http://localhost:5173").
+// Blank url keeps production mode, so it's convenient to pass a value from the environment.
+func WithViteDevServer(url string) ViteOption {
+	return func(v *Vite) error {
+		v.devServerURL = strings.TrimSuffix(url, "/")
+		return nil
+	}
+}
+
+// IsDev reports whether Vite is in development mode.
+func (v *Vite) IsDev() bool {
+	return v.devServerURL != ""
+}
+
+// Version returns the checksum of the build manifest,
+// so it can be used as Inertia's asset version. It's blank in development mode.
+func (v *Vite) Version() string {
+	return v.version
+}
+
+// Tags returns the html tags, that load passed entries (e.g. "resources/js/app.ts"):
+// scripts, stylesheets and module preloads of the imported chunks.
+func (v *Vite) Tags(entries ...string) (template.HTML, error) {
+	if v.IsDev() {
+		return v.devTags(entries), nil
+	}
+
+	assets, err := v.assets(entries)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for _, url := range assets.css {
+		sb.WriteString(`<link rel="stylesheet" href="`)
+		sb.WriteString(template.HTMLEscapeString(url))
+		sb.WriteString(`">`)
+	}
+	for _, url := range assets.preloads {
+		sb.WriteString(`<link rel="modulepreload" href="`)
+		sb.WriteString(template.HTMLEscapeString(url))
+		sb.WriteString(`">`)
+	}
+	for _, url := range assets.scripts {
+		sb.WriteString(`<script type="module" src="`)
+		sb.WriteString(template.HTMLEscapeString(url))
+		sb.WriteString(`"></script>`)
+	}
+
+	return template.HTML(sb.String()), nil
+}
+
+func (v *Vite) devTags(entries []string) template.HTML {
+	var sb strings.Builder
+
+	for _, entry := range append([]string{viteClientEntry}, entries...) {
+		url := template.HTMLEscapeString(v.devServerURL + "/" + entry)
+
+		if isCSSPath(entry) {
+			sb.WriteString(`<link rel="stylesheet" href="`)
+			sb.WriteString(url)
+			sb.WriteString(`">`)
+			continue
+		}
+
+		sb.WriteString(`<script type="module" src="`)
+		sb.WriteString(url)
+		sb.WriteString(`"></script>`)
+	}
+
+	return template.HTML(sb.String())
+}
+
+// viteAssets contains urls of the assets, that are required by the entries.
+type viteAssets struct {
+	scripts  []string
+	css      []string
+	preloads []string
+}
+
+func (v *Vite) assets(entries []string) (viteAssets, error) {
+	var assets viteAssets
+
+	seen := make(map[string]struct{})
+	add := func(list *[]string, file string) {
+		url := v.buildURL + file
+		if _, ok := seen[url]; ok {
+			return
+		}
+		seen[url] = struct{}{}
+		*list = append(*list, url)
+	}
+
+	// Chunks can import each other in a cycle, so every chunk is walked once.
+	visited := make(map[string]struct{})
+
+	var walk func(name string, entry bool) error
+	walk = func(name string, entry bool) error {
+		if _, ok := visited[name]; ok {
+			return nil
+		}
+		visited[name] = struct{}{}
+
+		chunk, ok := v.manifest[name]
+		if !ok {
+			return fmt.Errorf("chunk %q not found in vite manifest", name)
+		}
+
+		switch {
+		case isCSSPath(chunk.File):
+			add(&assets.css, chunk.File)
+		case entry:
+			add(&assets.scripts, chunk.File)
+		default:
+			add(&assets.preloads, chunk.File)
+		}
+
+		for _, css := range chunk.CSS {
+			add(&assets.css, css)
+		}
+
+		for _, imported := range chunk.Imports {
+			if err := walk(imported, false); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for _, entry := range entries {
+		if err := walk(entry, true); err != nil {
+			return viteAssets{}, err
+		}
+	}
+
+	return assets, nil
+}
+
+// linkHeaders returns the Link header values, that preload the assets of the passed entries.
+func (v *Vite) linkHeaders(entries []string) ([]string, error) {
+	assets, err := v.assets(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]string, 0, len(assets.css)+len(assets.preloads)+len(assets.scripts))
+	for _, url := range assets.css {
+		links = append(links, "<"+url+">; rel=preload; as=style")
+	}
+	for _, url := range assets.scripts {
+		links = append(links, "<"+url+">; rel=modulepreload")
+	}
+	for _, url := range assets.preloads {
+		links = append(links, "<"+url+">; rel=modulepreload")
+	}
+
+	return links, nil
+}
+
+// hasChunk reports whether the chunk exists in the build manifest.
+func (v *Vite) hasChunk(name string) bool {
+	_, ok := v.manifest[name]
+	return ok
+}
+
+func (v *Vite) readManifest() error {
+	bs, err := os.ReadFile(v.manifestPath)
+	if err != nil {
+		return fmt.Errorf("read vite manifest %q: %w", v.manifestPath, err)
+	}
+
+	var manifest map[string]viteChunk
+	if err = json.Unmarshal(bs, &manifest); err != nil {
+		return fmt.Errorf("json decode vite manifest: %w", err)
+	}
+
+	v.manifest = manifest
+	v.version = md5(string(bs))
+
+	return nil
+}
+
+func isCSSPath(p string) bool {
+	switch path.Ext(p) {
+	case ".css", ".scss", ".sass", ".less", ".styl", ".stylus", ".pcss", ".postcss":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+package gonertia
+
+import (
+	"html/template"
+	"testing"
+)
+
+var viteManifest = `{
+  "resources/js/app.ts": {
+    "file": "assets/app-4ed993c7.js",
+    "src": "resources/js/app.ts",
+    "isEntry": true,
+    "imports": ["_shared-b76e2dd1.js"],
+    "css": ["assets/app-5f4e8a1b.css"]
+  },
+  "resources/js/Pages/Home.vue": {
+    "file": "assets/Home-0a1b2c3d.js",
+    "src": "resources/js/Pages/Home.vue",
+    "isDynamicEntry": true,
+    "imports": ["_shared-b76e2dd1.js"]
+  },
+  "_shared-b76e2dd1.js": {
+    "file": "assets/shared-b76e2dd1.js",
+    "css": ["assets/shared-0f1e2d3c.css"]
+  },
+  "resources/css/app.css": {
+    "file": "assets/app-9a8b7c6d.css",
+    "src": "resources/css/app.css",
+    "isEntry": true
+  }
+}`
+
+// viteCyclicManifest contains chunks, that import each other in a cycle.
+var viteCyclicManifest = `{
+  "a.js": {
+    "file": "assets/a.js",
+    "isEntry": true,
+    "imports": ["_b.js", "_c.js"]
+  },
+  "_b.js": {
+    "file": "assets/b.js",
+    "imports": ["_c.js"]
+  },
+  "_c.js": {
+    "file": "assets/c.js",
+    "imports": ["_b.js"]
+  }
+}`
+
+func TestNewVite(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		f := tmpFile(t, viteManifest)
+
+		v, err := NewVite(f.Name(), WithViteBuildURL("/assets/build"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if v.IsDev() {
+			t.Fatal("vite is in development mode")
+		}
+
+		if v.buildURL != "/assets/build/" {
+			t.Fatalf("build url=%s, want=%s", v.buildURL, "/assets/build/")
+		}
+
+		if len(v.manifest) != 4 {
+			t.Fatalf("manifest chunks count=%d, want=%d", len(v.manifest), 4)
+		}
+
+		if want := md5(viteManifest); v.Version() != want {
+			t.Fatalf("version=%s, want=%s", v.Version(), want)
+		}
+	})
+
+	t.Run("dev server", func(t *testing.T) {
+		t.Parallel()
+
+		v, err := NewVite("not-exists.json", WithViteDevServer("http://localhost:5173/"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if !v.IsDev() {
+			t.Fatal("vite is not in development mode")
+		}
+
+		if v.Version() != "" {
+			t.Fatalf("version=%s, want empty", v.Version())
+		}
+	})
+
+	t.Run("manifest not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewVite("not-exists.json")
+		if err == nil {
+			t.Fatal("error expected")
+		}
+	})
+
+	t.Run("invalid manifest", func(t *testing.T) {
+		t.Parallel()
+
+		f := tmpFile(t, "foo")
+
+		_, err := NewVite(f.Name())
+		if err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
+
+func TestVite_Tags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		manifest string
+		opts     []ViteOption
+		entries  []string
+		want     template.HTML
+		wantErr  bool
+	}{
+		{
+			name:    "script entry",
+			entries: []string{"resources/js/app.ts"},
+			want: `<link rel="stylesheet" href="/build/assets/app-5f4e8a1b.css">` +
+				`<link rel="stylesheet" href="/build/assets/shared-0f1e2d3c.css">` +
+				`<link rel="modulepreload" href="/build/assets/shared-b76e2dd1.js">` +
+				`<script type="module" src="/build/assets/app-4ed993c7.js"></script>`,
+		},
+		{
+			name:    "multiple entries with shared chunks",
+			entries: []string{"resources/css/app.css", "resources/js/app.ts", "resources/js/Pages/Home.vue"},
+			want: `<link rel="stylesheet" href="/build/assets/app-9a8b7c6d.css">` +
+				`<link rel="stylesheet" href="/build/assets/app-5f4e8a1b.css">` +
+				`<link rel="stylesheet" href="/build/assets/shared-0f1e2d3c.css">` +
+				`<link rel="modulepreload" href="/build/assets/shared-b76e2dd1.js">` +
+				`<script type="module" src="/build/assets/app-4ed993c7.js"></script>` +
+				`<script type="module" src="/build/assets/Home-0a1b2c3d.js"></script>`,
+		},
+		{
+			name:     "circular imports",
+			manifest: viteCyclicManifest,
+			entries:  []string{"a.js"},
+			want: `<link rel="modulepreload" href="/build/assets/b.js">` +
+				`<link rel="modulepreload" href="/build/assets/c.js">` +
+				`<script type="module" src="/build/assets/a.js"></script>`,
+		},
+		{
+			name:    "unknown entry",
+			entries: []string{"resources/js/unknown.ts"},
+			wantErr: true,
+		},
+		{
+			name:    "dev server",
+			opts:    []ViteOption{WithViteDevServer("http://localhost:5173")},
+			entries: []string{"resources/css/app.css", "resources/js/app.ts"},
+			want: `<script type="module" src="http://localhost:5173/@vite/client"></script>` +
+				`<link rel="stylesheet" href="http://localhost:5173/resources/css/app.css">` +
+				`<script type="module" src="http://localhost:5173/resources/js/app.ts"></script>`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			manifest := tt.manifest
+			if manifest == "" {
+				manifest = viteManifest
+			}
+
+			f := tmpFile(t, manifest)
+
+			v, err := NewVite(f.Name(), tt.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			got, err := v.Tags(tt.entries...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error=%v, wantErr=%t", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Fatalf("tags=%s, want=%s", got, tt.want)
+			}
+		})
+	}
+}